@@ -3,10 +3,12 @@
 // license that can be found in the LICENSE file.
 
 // Package clock specifies a time of day with resolution to the nearest millisecond.
+// The Nano type is also provided for times of day with nanosecond resolution.
 //
 package clock
 
 import (
+	"fmt"
 	"math"
 	"time"
 )
@@ -71,6 +73,19 @@ func NewAt(t time.Time) Clock {
 	return Clock(hx + mx + sx + ms)
 }
 
+// FromHHMMSS returns a new Clock corresponding to an integer of the form HHMMSS, such as
+// 93015 for 09:30:15. The hours must be in the range 0 to 23 and the minutes and seconds
+// in the range 0 to 59, except that 240000 is allowed as the end-of-day midnight (see Day).
+func FromHHMMSS(v int) (Clock, error) {
+	h := v / 10000
+	m := (v / 100) % 100
+	s := v % 100
+	if v < 0 || h > 24 || m > 59 || s > 59 || (h == 24 && v != 240000) {
+		return 0, fmt.Errorf("clock.Clock: cannot convert %d: out of range", v)
+	}
+	return New(h, m, s, 0), nil
+}
+
 // SinceMidnight returns a new Clock based on a duration since some arbitrary midnight.
 func SinceMidnight(d time.Duration) Clock {
 	return Clock(d / time.Millisecond)
@@ -148,6 +163,17 @@ func (c Clock) Mod24() Clock {
 	return c - (q * Day)
 }
 
+// HHMMSS gets the clock-face time as an integer of the form HHMMSS, such as 93015 for
+// 09:30:15. It is calculated from the modulo time (see Mod24); any milliseconds are
+// discarded. Note the special case of midnight at the end of a day is 240000.
+func (c Clock) HHMMSS() int {
+	if c == Day {
+		return 240000
+	}
+	cm := c.Mod24()
+	return int(clockHours(cm)*10000 + clockMinutes(cm)*100 + clockSeconds(cm))
+}
+
 // Days gets the number of whole days represented by the Clock, assuming that each day is a fixed
 // 24 hour period. Negative values are treated so that the range -23h59m59s to -1s is fully
 // enclosed in a day numbered -1, and so on. This means that the result is zero only for the
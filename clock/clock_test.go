@@ -31,6 +31,42 @@ func TestClockHoursMinutesSeconds(t *testing.T) {
 	}
 }
 
+func TestClockHHMMSS(t *testing.T) {
+	cases := []struct {
+		in Clock
+		v  int
+	}{
+		{New(0, 0, 0, 0), 0},
+		{New(9, 30, 15, 0), 93015},
+		{New(9, 30, 15, 999), 93015},
+		{New(23, 59, 59, 0), 235959},
+		{Day, 240000},
+		{New(25, 0, 0, 0), 10000},
+	}
+	for i, x := range cases {
+		v := x.in.HHMMSS()
+		if v != x.v {
+			t.Errorf("%d: got %d, want %d", i, v, x.v)
+		}
+		c, err := FromHHMMSS(x.v)
+		if err != nil {
+			t.Errorf("%d: got %v", i, err)
+		} else if c.HHMMSS() != x.v {
+			t.Errorf("%d: got %v, want %d", i, c, x.v)
+		}
+	}
+}
+
+func TestClockFromHHMMSSErrors(t *testing.T) {
+	cases := []int{-1, 60, 6000, 250000, 240001, 1000000}
+	for _, x := range cases {
+		c, err := FromHHMMSS(x)
+		if err == nil {
+			t.Errorf("%d, got %v, want err", x, c)
+		}
+	}
+}
+
 func TestClockSinceMidnight(t *testing.T) {
 	cases := []struct {
 		in Clock
@@ -310,6 +346,35 @@ func TestClockParseGoods(t *testing.T) {
 		{"1:20:30.04pm", New(13, 20, 30, 40)},
 		{"1:20:30.4pm", New(13, 20, 30, 400)},
 		{"1:20:30.pm", New(13, 20, 30, 0)},
+		{"1:02:03.0045pm", New(13, 2, 3, 4)},
+		{"00-00", New(0, 0, 0, 0)},
+		{"00:00-00", New(0, 0, 0, 0)},
+		{"00:00:00-00", New(0, 0, 0, 0)},
+		{"T10:15:30", New(10, 15, 30, 0)},
+		{"10:15:30,5", New(10, 15, 30, 500)},
+		{"10.5", New(10, 30, 0, 0)},
+		{"10,25", New(10, 15, 0, 0)},
+		{"10:15.5", New(10, 15, 30, 0)},
+		{"1015.5", New(10, 15, 30, 0)},
+		{"101530.123456789", New(10, 15, 30, 123)},
+		{"10:15:30Z", New(10, 15, 30, 0)},
+		{"10:15:30.5+05:30", New(10, 15, 30, 500)},
+		{"T1015-0800", New(10, 15, 0, 0)},
+		{"24:00", Day},
+		{"24:00:01", New(24, 0, 1, 0)},
+		{"25:00", New(25, 0, 0, 0)},
+		{"26:30", New(26, 30, 0, 0)},
+		{"10:60", New(11, 0, 0, 0)},
+		{"10:15:60", New(10, 16, 0, 0)},
+		{"9h30", New(9, 30, 0, 0)},
+		{"21H05", New(21, 5, 0, 0)},
+		{"9h", New(9, 0, 0, 0)},
+		{"9.30am", New(9, 30, 0, 0)},
+		{"9.30 p.m.", New(21, 30, 0, 0)},
+		{"2:45 PM", New(14, 45, 0, 0)},
+		{"noon", New(12, 0, 0, 0)},
+		{"Midday", New(12, 0, 0, 0)},
+		{"midnight", New(0, 0, 0, 0)},
 	}
 	for _, x := range cases {
 		str := MustParse(x.str)
@@ -327,11 +392,8 @@ func TestClockParseBads(t *testing.T) {
 		{"0:01"},
 		{"0:00:01"},
 		{"hh"},
-		{"00-00"},
-		{"00:00-00"},
 		{"00:00:00-"},
 		{"00:00:00-0"},
-		{"00:00:00-00"},
 		{"00:00:00-000"},
 		{"00:mm"},
 		{"00:00:ss"},
@@ -350,7 +412,23 @@ func TestClockParseBads(t *testing.T) {
 		{"1:02:03-4pm"},
 		{"1:02:03-04pm"},
 		{"1:02:03-004pm"},
-		{"1:02:03.0045pm"},
+		{"T"},
+		{"10:15:30.5.5"},
+		{"10:15:30,"},
+		{"10:5"},
+		{"10:15:30:45"},
+		{"1015:30"},
+		{"10:15+2"},
+		{"10:15+02:0"},
+		{"10:15Zx"},
+		{"13pm"},
+		{"9.30.15am"},
+		{"9:3am"},
+		{"25h"},
+		{"24h01"},
+		{"9h3"},
+		{"h30"},
+		{"noonish"},
 	}
 	for _, x := range cases {
 		c, err := Parse(x.str)
@@ -361,3 +439,112 @@ func TestClockParseBads(t *testing.T) {
 		}
 	}
 }
+
+func TestClockParseISOAndHuman(t *testing.T) {
+	if c, err := ParseISO("T10:15:30,25Z"); err != nil || c != New(10, 15, 30, 250) {
+		t.Errorf("got %v, %v", c, err)
+	}
+	if _, err := ParseISO("10:15am"); err == nil {
+		t.Errorf("want err")
+	}
+	for _, s := range []string{"25:00", "24:00:01", "24.5", "10:60", "10:15:60"} {
+		if c, err := ParseISO(s); err == nil {
+			t.Errorf("%s, got %#v, want err", s, c)
+		}
+	}
+	if c, err := ParseHuman(" 10:15 AM "); err != nil || c != New(10, 15, 0, 0) {
+		t.Errorf("got %v, %v", c, err)
+	}
+	if _, err := ParseHuman("10:15"); err == nil {
+		t.Errorf("want err")
+	}
+}
+
+func TestClockParseError(t *testing.T) {
+	cases := []struct {
+		str, reason, msg string
+	}{
+		{"25h", "hour out of range", "clock.Clock: cannot parse 25h: hour out of range"},
+		{"10:15:30,", "invalid fraction", "clock.Clock: cannot parse 10:15:30,: invalid fraction"},
+		{"10:15+2", "invalid UTC offset", "clock.Clock: cannot parse 10:15+2: invalid UTC offset"},
+		{"teatime", "unrecognised time", "clock.Clock: cannot parse teatime: unrecognised time"},
+	}
+	for _, x := range cases {
+		_, err := Parse(x.str)
+		pe, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("%s, got %#v", x.str, err)
+		} else if pe.Value != x.str || pe.Reason != x.reason || pe.Error() != x.msg {
+			t.Errorf("%s, got %#v, %q", x.str, pe, pe.Error())
+		}
+	}
+}
+
+func TestClockParseSQL(t *testing.T) {
+	cases := []struct {
+		str  string
+		want Clock
+	}{
+		{"00:00", New(0, 0, 0, 0)},
+		{"10:15:30", New(10, 15, 30, 0)},
+		{"10:15:30.1", New(10, 15, 30, 100)},
+		{"10:15:30.123456", New(10, 15, 30, 123)},
+		{"10:15:30.999999999", New(10, 15, 30, 999)},
+		{"24:00:00", Day},
+		{"24:00:00.000000", Day},
+		{"838:59:59", New(838, 59, 59, 0)},
+		{" 10:00:00 ", New(10, 0, 0, 0)},
+		{"10:00:00+02", New(10, 0, 0, 0)},
+		{"10:00:00-0530", New(10, 0, 0, 0)},
+		{"10:00:00.25Z", New(10, 0, 0, 250)},
+	}
+	for _, x := range cases {
+		c, err := ParseSQL(x.str)
+		if err != nil {
+			t.Errorf("%s, got %v", x.str, err)
+		} else if c != x.want {
+			t.Errorf("%s, got %v, want %v", x.str, c, x.want)
+		}
+	}
+}
+
+func TestClockParseSQLBads(t *testing.T) {
+	cases := []string{
+		"",
+		"1",
+		"1:00",
+		"10",
+		"10:0",
+		"10:00:0",
+		"10:00:00.",
+		"10:00:00,5",
+		"10:00:00x",
+		"-10:00:00",
+		"10:00:00+2",
+		"10:00:00+02:0",
+		"10:00:00+xx",
+		"10:00:00+-1",
+		"10:00:00+02:60",
+		"10:00:00+0275",
+		"10:00:00+24",
+		"10:00:00+02:00:60",
+		"10:15:30.123abc",
+		"10:15:30.12a",
+		"10:15:30.1234x",
+		"10:15:30.12.3",
+		"hh:mm:ss",
+		"10:75:00",
+		"10:00:60",
+		"838:60:00",
+		"-01:00:00",
+	}
+	for _, x := range cases {
+		c, err := ParseSQL(x)
+		if err == nil {
+			t.Errorf("%s, got %#v, want err", x, c)
+		}
+	}
+	if _, err := ParseSQL("-01:00:00"); err == nil || err.(*ParseError).Reason != "negative times are not supported" {
+		t.Errorf("got %v", err)
+	}
+}
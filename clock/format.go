@@ -104,3 +104,87 @@ func (c Clock) String() string {
 	cm := c.Mod24()
 	return fmt.Sprintf("%02d:%02d:%02d.%03d", clockHours(cm), clockMinutes(cm), clockSeconds(cm), clockMillisec(cm))
 }
+
+//-------------------------------------------------------------------------------------------------
+
+func nanoHours(nm Nano) Nano {
+	return nm / nanoHour
+}
+
+func nanoMinutes(nm Nano) Nano {
+	return (nm % nanoHour) / nanoMinute
+}
+
+func nanoSeconds(nm Nano) Nano {
+	return (nm % nanoMinute) / nanoSecond
+}
+
+func nanoFraction(nm Nano) Nano {
+	return nm % nanoSecond
+}
+
+// Hh gets the clock-face number of hours as a two-digit string. See Clock.Hh.
+func (n Nano) Hh() string {
+	return n.clock().Hh()
+}
+
+// HhMm gets the clock-face number of hours and minutes as a five-character ISO-8601 time string.
+// See Clock.HhMm.
+func (n Nano) HhMm() string {
+	return n.clock().HhMm()
+}
+
+// HhMmSs gets the clock-face number of hours, minutes, seconds as an eight-character ISO-8601
+// time string. See Clock.HhMmSs.
+func (n Nano) HhMmSs() string {
+	return n.clock().HhMmSs()
+}
+
+// Hh12 gets the clock-face number of hours as a one- or two-digit string, followed by am or pm.
+// See Clock.Hh12.
+func (n Nano) Hh12() string {
+	return n.clock().Hh12()
+}
+
+// HhMm12 gets the clock-face number of hours and minutes, followed by am or pm.
+// See Clock.HhMm12.
+func (n Nano) HhMm12() string {
+	return n.clock().HhMm12()
+}
+
+// HhMmSs12 gets the clock-face number of hours, minutes and seconds, followed by am or pm.
+// See Clock.HhMmSs12.
+func (n Nano) HhMmSs12() string {
+	return n.clock().HhMmSs12()
+}
+
+// clock gets the equivalent Clock, retaining the end-of-day midnight.
+func (n Nano) clock() Clock {
+	if n == NanoDay {
+		return Day
+	}
+	return n.Mod24().Clock()
+}
+
+// HhMmSsFraction gets the clock-face time as an ISO-8601 time string with a specified number
+// of decimal places for the seconds, from 0 to 9. Excess digits are truncated, so
+// 10:20:30.456789 with 3 decimal places gives "10:20:30.456".
+// It is calculated from the modulo time; see Mod24.
+// Note the special case of midnight at the end of a day is "24:00:00" followed by zeros.
+func (n Nano) HhMmSsFraction(places int) string {
+	if places <= 0 {
+		return n.HhMmSs()
+	}
+	if places > 9 {
+		places = 9
+	}
+	fraction := fmt.Sprintf("%09d", nanoFraction(n.Mod24()))
+	return n.HhMmSs() + "." + fraction[:places]
+}
+
+// String gets the clock-face number of hours, minutes, seconds and nanoseconds as an 18-character
+// ISO-8601 time string (calculated from the modulo time, see Mod24), specified to the nearest
+// nanosecond. Note the special case of midnight at the end of a day is "24:00:00.000000000".
+func (n Nano) String() string {
+	return n.HhMmSsFraction(9)
+}
@@ -0,0 +1,347 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The layout tokens are a subset of those used by the time package, using the same
+// reference time, i.e. 15:04:05.
+const (
+	tokNone     = iota
+	tokHour     // "15"
+	tokHour12   // "3"
+	tokZHour12  // "03"
+	tokMinute   // "4"
+	tokZMinute  // "04"
+	tokSecond   // "5"
+	tokZSecond  // "05"
+	tokPM       // "PM"
+	tokLowerPM  // "pm"
+	tokFracZero // ".000" or ",000" - fixed number of digits
+	tokFracNine // ".999" or ",999" - trailing zeros omitted
+)
+
+// nextToken finds the next layout token, returning the literal prefix before it, the
+// token, the number of fraction digits (if any) and the rest of the layout after it.
+// The separator of a fraction ('.' or ',') is the byte just after the prefix.
+func nextToken(layout string) (prefix string, tok, digits int, suffix string) {
+	for i := 0; i < len(layout); i++ {
+		switch c := layout[i]; c {
+		case '1':
+			if strings.HasPrefix(layout[i:], "15") {
+				return layout[:i], tokHour, 0, layout[i+2:]
+			}
+		case '0':
+			if i+1 < len(layout) && '3' <= layout[i+1] && layout[i+1] <= '5' {
+				return layout[:i], []int{tokZHour12, tokZMinute, tokZSecond}[layout[i+1]-'3'], 0, layout[i+2:]
+			}
+		case '3':
+			return layout[:i], tokHour12, 0, layout[i+1:]
+		case '4':
+			return layout[:i], tokMinute, 0, layout[i+1:]
+		case '5':
+			return layout[:i], tokSecond, 0, layout[i+1:]
+		case 'P':
+			if strings.HasPrefix(layout[i:], "PM") {
+				return layout[:i], tokPM, 0, layout[i+2:]
+			}
+		case 'p':
+			if strings.HasPrefix(layout[i:], "pm") {
+				return layout[:i], tokLowerPM, 0, layout[i+2:]
+			}
+		case '.', ',':
+			if i+1 < len(layout) && (layout[i+1] == '0' || layout[i+1] == '9') {
+				ch := layout[i+1]
+				j := i + 1
+				for j < len(layout) && layout[j] == ch {
+					j++
+				}
+				// a fraction must not be followed by another digit
+				if j == len(layout) || !isDigit(layout[j]) {
+					if ch == '0' {
+						return layout[:i], tokFracZero, j - i - 1, layout[j:]
+					}
+					return layout[:i], tokFracNine, j - i - 1, layout[j:]
+				}
+			}
+		}
+	}
+	return layout, tokNone, 0, ""
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// Format returns a textual representation of the clock value formatted according to
+// layout, which uses the same reference time as the time package, i.e. 15:04:05. The
+// recognised tokens are
+//
+//	15    hours (24-hour clock)
+//	3 03  hours (12-hour clock), without or with a leading zero
+//	4 04  minutes, without or with a leading zero
+//	5 05  seconds, without or with a leading zero
+//	PM pm the AM/PM marker, in upper or lower case
+//	.000  the fraction of a second, to a fixed number of digits (up to three)
+//	.999  the fraction of a second, omitting trailing zeros
+//
+// A comma can be used instead of the decimal point. Everything else is copied verbatim.
+//
+// Unlike time.Time, the 24-hour clock is not limited to the range 0 to 23: times past
+// midnight (i.e. for which IsInOneDay is false) give more than 24 hours, so New(26, 30, 0, 0)
+// formatted with "15:04" gives "26:30", and Day gives "24:00". Negative values are
+// formatted from the modulo time; see Mod24. The 12-hour clock always uses the modulo time.
+func (c Clock) Format(layout string) string {
+	return formatLayout(layout, c.DurationSinceMidnight(), 3)
+}
+
+// Format returns a textual representation of the clock value formatted according to
+// layout, as for Clock.Format. The fraction of a second can have up to nine digits.
+func (n Nano) Format(layout string) string {
+	return formatLayout(layout, n.DurationSinceMidnight(), 9)
+}
+
+func formatLayout(layout string, d time.Duration, maxDigits int) string {
+	if d < 0 {
+		d = d % (24 * time.Hour)
+		if d < 0 {
+			d += 24 * time.Hour
+		}
+	}
+
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	seconds := int(d % time.Minute / time.Second)
+	nanos := int(d % time.Second)
+
+	h12 := hours % 12
+	if h12 == 0 {
+		h12 = 12
+	}
+	pm := hours%24 >= 12
+
+	buf := &strings.Builder{}
+	for layout != "" {
+		prefix, tok, digits, suffix := nextToken(layout)
+		buf.WriteString(prefix)
+		sep := layout[minInt(len(prefix), len(layout)-1)]
+		layout = suffix
+
+		switch tok {
+		case tokHour:
+			fmt.Fprintf(buf, "%02d", hours)
+		case tokHour12:
+			buf.WriteString(strconv.Itoa(h12))
+		case tokZHour12:
+			fmt.Fprintf(buf, "%02d", h12)
+		case tokMinute:
+			buf.WriteString(strconv.Itoa(minutes))
+		case tokZMinute:
+			fmt.Fprintf(buf, "%02d", minutes)
+		case tokSecond:
+			buf.WriteString(strconv.Itoa(seconds))
+		case tokZSecond:
+			fmt.Fprintf(buf, "%02d", seconds)
+		case tokPM:
+			buf.WriteString(map[bool]string{false: "AM", true: "PM"}[pm])
+		case tokLowerPM:
+			buf.WriteString(map[bool]string{false: "am", true: "pm"}[pm])
+		case tokFracZero, tokFracNine:
+			frac := fmt.Sprintf("%09d", nanos)[:minInt(digits, maxDigits)]
+			if tok == tokFracNine {
+				frac = strings.TrimRight(frac, "0")
+				if frac == "" {
+					continue
+				}
+			}
+			buf.WriteByte(sep)
+			buf.WriteString(frac)
+		}
+	}
+	return buf.String()
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// MustParseLayout is as per ParseLayout except that it panics if the string cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustParseLayout(layout, value string) Clock {
+	c, err := ParseLayout(layout, value)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// ParseLayout parses a formatted string and returns the clock value it represents.
+// The layout defines the format by showing how the reference time, 15:04:05, would be
+// displayed; the tokens are the same as for Clock.Format.
+//
+// The 24-hour clock may exceed 24 hours, so "26:30" parsed with "15:04" gives
+// New(26, 30, 0, 0). When the 12-hour clock is used, the hours must be in the range
+// 1 to 12 and the AM/PM marker, if present, is case-insensitive. As with the time package,
+// a fraction of a second is accepted after the seconds even if the layout does not
+// include one; any digits beyond milliseconds are truncated.
+func ParseLayout(layout, value string) (Clock, error) {
+	d, err := parseLayout(layout, value, "clock.Clock")
+	if err != nil {
+		return 0, err
+	}
+	return SinceMidnight(d), nil
+}
+
+// ParseNanoLayout parses a formatted string and returns the clock value it represents,
+// as per ParseLayout except that the fraction of a second is kept to the nearest nanosecond.
+func ParseNanoLayout(layout, value string) (Nano, error) {
+	d, err := parseLayout(layout, value, "clock.Nano")
+	if err != nil {
+		return 0, err
+	}
+	return NanoSinceMidnight(d), nil
+}
+
+func parseLayout(layout, value, typ string) (time.Duration, error) {
+	original := value
+	hours, minutes, seconds, nanos := 0, 0, 0, 0
+	hour12, pmSet, pm := false, false, false
+
+	fail := func(what string) (time.Duration, error) {
+		return 0, &ParseError{Type: typ, Value: original, Layout: layout, Reason: what}
+	}
+
+	remaining := layout
+	for remaining != "" {
+		prefix, tok, digits, suffix := nextToken(remaining)
+		remaining = suffix
+
+		if !strings.HasPrefix(value, prefix) {
+			return fail(fmt.Sprintf("expected %q", prefix))
+		}
+		value = value[len(prefix):]
+
+		var err error
+		switch tok {
+		case tokHour:
+			// hours past midnight can have more than two digits, unless another
+			// numeric field follows without a separator
+			max := 0
+			if next, nt, _, _ := nextToken(remaining); next == "" && nt != tokNone {
+				max = 2
+			}
+			hours, value, err = parseDigits(value, 1, max)
+		case tokHour12, tokZHour12:
+			hours, value, err = parseDigits(value, 1, 2)
+			if err == nil && (hours < 1 || hours > 12) {
+				return fail("hour out of range")
+			}
+			hour12 = true
+		case tokMinute, tokZMinute:
+			minutes, value, err = parseDigits(value, 1+tokIsPadded(tok), 2)
+			if err == nil && minutes > 59 {
+				return fail("minute out of range")
+			}
+		case tokSecond, tokZSecond:
+			seconds, value, err = parseDigits(value, 1+tokIsPadded(tok), 2)
+			if err == nil && seconds > 59 {
+				return fail("second out of range")
+			}
+			// as per the time package, a fraction can follow the seconds
+			if err == nil && len(value) > 1 && (value[0] == '.' || value[0] == ',') && isDigit(value[1]) {
+				if _, next, _, _ := nextToken(remaining); next != tokFracZero && next != tokFracNine {
+					nanos, value, err = parseFraction(value[1:], 1)
+				}
+			}
+		case tokPM, tokLowerPM:
+			if len(value) < 2 {
+				return fail("expected AM or PM")
+			}
+			switch strings.ToUpper(value[:2]) {
+			case "AM":
+			case "PM":
+				pm = true
+			default:
+				return fail("expected AM or PM")
+			}
+			pmSet = true
+			value = value[2:]
+		case tokFracZero, tokFracNine:
+			if len(value) == 0 || (value[0] != '.' && value[0] != ',') {
+				return fail("expected a fraction")
+			}
+			min := digits
+			if tok == tokFracNine {
+				min = 1
+			}
+			nanos, value, err = parseFraction(value[1:], min)
+		}
+
+		if err != nil {
+			return fail(err.Error())
+		}
+	}
+
+	if value != "" {
+		return fail(fmt.Sprintf("unexpected %q", value))
+	}
+
+	if hour12 || pmSet {
+		hours = hours % 12
+		if pm {
+			hours += 12
+		}
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second + time.Duration(nanos), nil
+}
+
+func tokIsPadded(tok int) int {
+	if tok == tokZMinute || tok == tokZSecond {
+		return 1
+	}
+	return 0
+}
+
+// parseDigits parses at least min digits and at most max digits (or any number if max is zero).
+func parseDigits(value string, min, max int) (int, string, error) {
+	i := 0
+	for i < len(value) && isDigit(value[i]) && (max == 0 || i < max) {
+		i++
+	}
+	if i < min {
+		return 0, value, fmt.Errorf("expected a number")
+	}
+	n, err := strconv.Atoi(value[:i])
+	return n, value[i:], err
+}
+
+// parseFraction parses the digits of a fraction of a second, returning nanoseconds.
+func parseFraction(value string, min int) (int, string, error) {
+	i := 0
+	for i < len(value) && isDigit(value[i]) {
+		i++
+	}
+	if i < min {
+		return 0, value, fmt.Errorf("expected a fraction")
+	}
+	digits := value[:i]
+	if len(digits) > 9 {
+		digits = digits[:9]
+	}
+	n, err := strconv.Atoi((digits + "00000000")[:9])
+	return n, value[i:], err
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
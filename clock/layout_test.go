@@ -0,0 +1,123 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"testing"
+)
+
+func TestClockFormat(t *testing.T) {
+	cases := []struct {
+		in     Clock
+		layout string
+		want   string
+	}{
+		{New(0, 0, 0, 0), "15:04:05", "00:00:00"},
+		{New(1, 2, 3, 4), "15:04:05.000", "01:02:03.004"},
+		{New(1, 2, 3, 40), "15:04:05.999", "01:02:03.04"},
+		{New(1, 2, 3, 0), "15:04:05.999", "01:02:03"},
+		{New(1, 2, 3, 450), "15:04:05,00", "01:02:03,45"},
+		{New(1, 2, 3, 0), "3:4:5", "1:2:3"},
+		{New(0, 30, 0, 0), "03:04 PM", "12:30 AM"},
+		{New(12, 30, 0, 0), "3:04pm", "12:30pm"},
+		{New(23, 5, 9, 0), "03:04:05 PM", "11:05:09 PM"},
+		{New(26, 30, 0, 0), "15:04", "26:30"},
+		{New(26, 30, 0, 0), "3:04 PM", "2:30 AM"},
+		{Day, "15:04", "24:00"},
+		{New(0, 0, 0, -1), "15:04:05.000", "23:59:59.999"},
+		{New(9, 8, 7, 0), "150405", "090807"},
+		{New(9, 8, 7, 0), "T15h", "T09h"},
+	}
+	for i, x := range cases {
+		got := x.in.Format(x.layout)
+		if got != x.want {
+			t.Errorf("%d: %v %q: got %q, want %q", i, x.in, x.layout, got, x.want)
+		}
+	}
+}
+
+func TestNanoFormat(t *testing.T) {
+	n := NewNano(1, 2, 3, 123456789)
+	if got := n.Format("15:04:05.000000000"); got != "01:02:03.123456789" {
+		t.Errorf("got %q", got)
+	}
+	if got := n.Format("15:04:05.000"); got != "01:02:03.123" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	cases := []struct {
+		layout, value string
+		want          Clock
+	}{
+		{"15:04:05", "00:00:00", New(0, 0, 0, 0)},
+		{"15:04:05", "01:02:03", New(1, 2, 3, 0)},
+		{"15:04:05", "01:02:03.456", New(1, 2, 3, 456)},
+		{"15:04:05.000", "01:02:03.004", New(1, 2, 3, 4)},
+		{"15:04:05.999", "01:02:03.4", New(1, 2, 3, 400)},
+		{"15:04:05,000", "01:02:03,004", New(1, 2, 3, 4)},
+		{"15:04", "26:30", New(26, 30, 0, 0)},
+		{"15:04", "100:00", New(100, 0, 0, 0)},
+		{"150405", "090807", New(9, 8, 7, 0)},
+		{"3:4:5", "1:2:3", New(1, 2, 3, 0)},
+		{"03:04 PM", "12:30 AM", New(0, 30, 0, 0)},
+		{"03:04 PM", "12:30 pm", New(12, 30, 0, 0)},
+		{"3:04pm", "11:05PM", New(23, 5, 0, 0)},
+	}
+	for i, x := range cases {
+		got, err := ParseLayout(x.layout, x.value)
+		if err != nil {
+			t.Errorf("%d: %q %q: %v", i, x.layout, x.value, err)
+		} else if got != x.want {
+			t.Errorf("%d: %q %q: got %v, want %v", i, x.layout, x.value, got, x.want)
+		}
+	}
+}
+
+func TestParseLayoutRoundTrip(t *testing.T) {
+	layouts := []string{"15:04:05.000", "150405.000", "03:04:05.000 PM"}
+	for _, layout := range layouts {
+		for _, c := range []Clock{New(0, 0, 0, 0), New(9, 8, 7, 6), New(12, 0, 0, 1), New(23, 59, 59, 999)} {
+			got := MustParseLayout(layout, c.Format(layout))
+			if got != c {
+				t.Errorf("%q: got %v, want %v", layout, got, c)
+			}
+		}
+	}
+}
+
+func TestParseNanoLayout(t *testing.T) {
+	got, err := ParseNanoLayout("15:04:05.000000000", "01:02:03.123456789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != NewNano(1, 2, 3, 123456789) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestParseLayoutBads(t *testing.T) {
+	cases := []struct {
+		layout, value string
+	}{
+		{"15:04", ""},
+		{"15:04", "1230"},
+		{"15:04", "12:60"},
+		{"15:04:05", "12:00:60"},
+		{"15:04", "12:3x"},
+		{"03:04 PM", "13:00 PM"},
+		{"03:04 PM", "00:00 AM"},
+		{"03:04 PM", "11:00 XM"},
+		{"15:04:05.000", "12:00:00"},
+		{"15:04", "aa:00"},
+	}
+	for i, x := range cases {
+		_, err := ParseLayout(x.layout, x.value)
+		if err == nil {
+			t.Errorf("%d: %q %q: expected error", i, x.layout, x.value)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package clock
 
 import (
 	"errors"
+	"strconv"
 )
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
@@ -41,3 +42,161 @@ func (c *Clock) UnmarshalText(data []byte) (err error) {
 	}
 	return err
 }
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The clock is given as the digits of its HHMMSS integer form (e.g. "93015").
+func (ci ClockInt) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(ci.Clock().HHMMSS())), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The clock is expected to be the digits of its HHMMSS integer form (e.g. "93015"),
+// although any form accepted by Parse is also allowed.
+func (ci *ClockInt) UnmarshalText(data []byte) (err error) {
+	var c Clock
+	n, err := strconv.Atoi(string(data))
+	if err == nil {
+		c, err = FromHHMMSS(n)
+	} else {
+		c, err = Parse(string(data))
+	}
+	if err == nil {
+		*ci = ClockInt(c)
+	}
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The clock is given as an HHMMSS number (e.g. 93015).
+func (ci ClockInt) MarshalJSON() ([]byte, error) {
+	return ci.MarshalText()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The clock is expected to be an HHMMSS number (e.g. 93015), although
+// a string is also allowed as per UnmarshalText.
+func (ci *ClockInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	return ci.UnmarshalText(data)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (oc OffsetClock) MarshalBinary() ([]byte, error) {
+	enc, _ := oc.clock.MarshalBinary()
+	off := Clock(oc.offset)
+	return append(enc, byte(off>>24), byte(off>>16), byte(off>>8), byte(off)), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (oc *OffsetClock) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("OffsetClock.UnmarshalBinary: no data")
+	}
+	if len(data) != 8 {
+		return errors.New("OffsetClock.UnmarshalBinary: invalid length")
+	}
+
+	var c, off Clock
+	c.UnmarshalBinary(data[:4])
+	off.UnmarshalBinary(data[4:])
+	*oc = OffsetClock{c, int32(off)}
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (oc OffsetClock) MarshalText() ([]byte, error) {
+	return []byte(oc.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (oc *OffsetClock) UnmarshalText(data []byte) (err error) {
+	u, err := ParseOffsetClock(string(data))
+	if err == nil {
+		*oc = u
+	}
+	return err
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (n Nano) MarshalBinary() ([]byte, error) {
+	enc := make([]byte, 8)
+	for i := 0; i < 8; i++ {
+		enc[i] = byte(n >> (56 - 8*i))
+	}
+	return enc, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (n *Nano) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("Nano.UnmarshalBinary: no data")
+	}
+	if len(data) != 8 {
+		return errors.New("Nano.UnmarshalBinary: invalid length")
+	}
+
+	var v Nano
+	for _, b := range data {
+		v = v<<8 | Nano(b)
+	}
+	*n = v
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (n Nano) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (n *Nano) UnmarshalText(data []byte) (err error) {
+	v, err := ParseNano(string(data))
+	if err == nil {
+		*n = v
+	}
+	return err
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (r Range) MarshalBinary() ([]byte, error) {
+	start, _ := r.start.MarshalBinary()
+	end, _ := r.end.MarshalBinary()
+	return append(start, end...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (r *Range) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("Range.UnmarshalBinary: no data")
+	}
+	if len(data) != 8 {
+		return errors.New("Range.UnmarshalBinary: invalid length")
+	}
+
+	var start, end Clock
+	start.UnmarshalBinary(data[:4])
+	end.UnmarshalBinary(data[4:])
+	*r = NewRange(start, end)
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The range is given as per String, e.g. "22:00-06:00".
+func (r Range) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The range is parsed as per ParseRange.
+func (r *Range) UnmarshalText(data []byte) (err error) {
+	u, err := ParseRange(string(data))
+	if err == nil {
+		*r = u
+	}
+	return err
+}
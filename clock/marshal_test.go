@@ -137,7 +137,7 @@ func TestInvalidClockText(t *testing.T) {
 	}{
 		{`not-a-clock`, `clock.Clock: cannot parse not-a-clock`},
 		{`00:50:100.0`, `clock.Clock: cannot parse 00:50:100.0`},
-		{`24:00:00.0pM`, `clock.Clock: cannot parse 24:00:00.0pM: strconv.Atoi: parsing "0pM": invalid syntax`},
+		{`24:00:00.0pM`, `clock.Clock: cannot parse 24:00:00.0pM: hour out of range`},
 	}
 	for _, c := range cases {
 		var clock Clock
@@ -147,3 +147,184 @@ func TestInvalidClockText(t *testing.T) {
 		}
 	}
 }
+
+func TestClockIntJSONMarshalling(t *testing.T) {
+	cases := []struct {
+		value Clock
+		want  string
+	}{
+		{New(0, 0, 0, 0), `0`},
+		{New(9, 30, 15, 0), `93015`},
+		{New(23, 59, 59, 0), `235959`},
+		{Day, `240000`},
+	}
+	for _, c := range cases {
+		bb, err := json.Marshal(c.value.ClockInt())
+		if err != nil {
+			t.Errorf("JSON(%v) marshal error %v", c, err)
+		} else if string(bb) != c.want {
+			t.Errorf("JSON(%v) == %v, want %v", c.value, string(bb), c.want)
+		} else {
+			var ci ClockInt
+			err = json.Unmarshal(bb, &ci)
+			if err != nil {
+				t.Errorf("JSON(%v) unmarshal error %v", c, err)
+			} else if ci.Clock() != c.value {
+				t.Errorf("JSON(%v) unmarshal got %v", c, ci.Clock())
+			}
+		}
+	}
+
+	var ci ClockInt
+	err := json.Unmarshal([]byte(`"4:20pm"`), &ci)
+	if err != nil || ci.Clock() != New(16, 20, 0, 0) {
+		t.Errorf("JSON unmarshal got %v %v", ci.Clock(), err)
+	}
+}
+
+func TestOffsetClockJSONMarshalling(t *testing.T) {
+	cases := []struct {
+		value OffsetClock
+		want  string
+	}{
+		{NewOffsetClock(New(0, 0, 0, 0), 0), `"00:00:00.000+00:00"`},
+		{NewOffsetClock(New(12, 40, 40, 80), 7200), `"12:40:40.080+02:00"`},
+		{NewOffsetClock(New(24, 0, 0, 0), -18000), `"24:00:00.000-05:00"`},
+	}
+	for _, c := range cases {
+		bb, err := json.Marshal(c.value)
+		if err != nil {
+			t.Errorf("JSON(%v) marshal error %v", c, err)
+		} else if string(bb) != c.want {
+			t.Errorf("JSON(%v) == %v, want %v", c.value, string(bb), c.want)
+		} else {
+			var oc OffsetClock
+			err = json.Unmarshal(bb, &oc)
+			if err != nil {
+				t.Errorf("JSON(%v) unmarshal error %v", c, err)
+			} else if oc != c.value {
+				t.Errorf("JSON(%v) unmarshal got %v", c, oc)
+			}
+		}
+	}
+}
+
+func TestOffsetClockBinaryMarshalling(t *testing.T) {
+	cases := []OffsetClock{
+		NewOffsetClock(New(0, 0, 0, 0), 0),
+		NewOffsetClock(New(12, 40, 40, 80), 7200),
+		NewOffsetClock(New(24, 0, 0, 0), -18000),
+	}
+	for _, c := range cases {
+		bb, err := c.MarshalBinary()
+		if err != nil {
+			t.Errorf("Binary(%v) marshal error %v", c, err)
+		} else {
+			var oc OffsetClock
+			err = oc.UnmarshalBinary(bb)
+			if err != nil {
+				t.Errorf("Binary(%v) unmarshal error %v", c, err)
+			} else if oc != c {
+				t.Errorf("Binary(%v) unmarshal got %v", c, oc)
+			}
+		}
+	}
+
+	var oc OffsetClock
+	if oc.UnmarshalBinary([]byte{}) == nil {
+		t.Errorf("unmarshal no empty data error")
+	}
+	if oc.UnmarshalBinary([]byte("12345")) == nil {
+		t.Errorf("unmarshal no wrong length error")
+	}
+}
+
+func TestNanoMarshalling(t *testing.T) {
+	cases := []Nano{
+		NewNano(-1, -1, -1, -1),
+		NewNano(0, 0, 0, 0),
+		NewNano(12, 40, 40, 80),
+		NewNano(13, 55, 0, 123456789),
+		NewNano(24, 0, 0, 0),
+		NewNano(2400, 0, 0, 1),
+	}
+	for _, c := range cases {
+		bb, err := c.MarshalBinary()
+		if err != nil {
+			t.Errorf("Binary(%v) marshal error %v", c, err)
+		} else {
+			var n Nano
+			err = n.UnmarshalBinary(bb)
+			if err != nil {
+				t.Errorf("Binary(%v) unmarshal error %v", c, err)
+			} else if n != c {
+				t.Errorf("Binary(%v) unmarshal got %v", c, n)
+			}
+		}
+
+		bb, err = json.Marshal(c)
+		if err != nil {
+			t.Errorf("JSON(%v) marshal error %v", c, err)
+		} else {
+			var n Nano
+			err = json.Unmarshal(bb, &n)
+			if err != nil {
+				t.Errorf("JSON(%v) unmarshal error %v", c, err)
+			} else if n.Mod24() != c.Mod24() {
+				t.Errorf("JSON(%v) unmarshal got %v", c, n)
+			}
+		}
+	}
+
+	var n Nano
+	if err := n.UnmarshalBinary([]byte{}); err == nil {
+		t.Errorf("unmarshal no empty data error")
+	}
+	if err := n.UnmarshalBinary([]byte("1234")); err == nil {
+		t.Errorf("unmarshal no wrong length error")
+	}
+	if err := n.UnmarshalText([]byte("not-a-clock")); err == nil || !strings.Contains(err.Error(), "clock.Nano: cannot parse not-a-clock") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestRangeMarshalling(t *testing.T) {
+	cases := []struct {
+		value Range
+		want  string
+	}{
+		{MustParseRange("09:00-17:30"), `"09:00-17:30"`},
+		{MustParseRange("22:00-06:00"), `"22:00-06:00"`},
+		{MustParseRange("00:00-24:00"), `"00:00-24:00"`},
+	}
+	for _, c := range cases {
+		bb, err := json.Marshal(c.value)
+		if err != nil {
+			t.Errorf("JSON(%v) marshal error %v", c.value, err)
+		} else if string(bb) != c.want {
+			t.Errorf("JSON(%v) == %v, want %v", c.value, string(bb), c.want)
+		} else {
+			var r Range
+			if err = json.Unmarshal(bb, &r); err != nil || r != c.value {
+				t.Errorf("JSON(%v) unmarshal got %v %v", c.value, r, err)
+			}
+		}
+
+		bb, _ = c.value.MarshalBinary()
+		var r Range
+		if err = r.UnmarshalBinary(bb); err != nil || r != c.value {
+			t.Errorf("Binary(%v) unmarshal got %v %v", c.value, r, err)
+		}
+	}
+
+	var r Range
+	if r.UnmarshalBinary([]byte{}) == nil {
+		t.Errorf("unmarshal no empty data error")
+	}
+	if r.UnmarshalBinary([]byte("12345")) == nil {
+		t.Errorf("unmarshal no wrong length error")
+	}
+	if json.Unmarshal([]byte(`"09:00"`), &r) == nil {
+		t.Errorf("unmarshal no invalid text error")
+	}
+}
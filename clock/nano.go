@@ -0,0 +1,166 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"time"
+)
+
+// Nano specifies a time of day with nanosecond resolution. It is the same as Clock in
+// every other respect: it is the time since midnight (on some arbitrary day in some
+// arbitrary timezone), it is not intended for periods greater than 24 hours nor
+// negative values, and Mod24 is provided to discard whole multiples of 24 hours.
+//
+// Nano is a type of integer (actually int64), so values can be compared and sorted as
+// per other integers. Its unit is the same as time.Duration, so Nano(time.Hour) is 1am.
+// The constants NanoDay and NanoNoon are also provided.
+//
+// Any Clock can be converted to a Nano without loss using Clock.Nano.
+type Nano int64
+
+const (
+	nanoSecond = Nano(time.Second)
+	nanoMinute = Nano(time.Minute)
+	nanoHour   = Nano(time.Hour)
+
+	// NanoDay is a fixed period of 24 hours. This does not take account of daylight
+	// savings, so is not fully general.
+	NanoDay = Nano(24 * time.Hour)
+
+	// NanoNoon is at 12pm.
+	NanoNoon = NanoDay / 2
+)
+
+// NewNano returns a new Nano with specified hour, minute, second and nanosecond.
+func NewNano(hour, minute, second, nanosec int) Nano {
+	hx := Nano(hour) * nanoHour
+	mx := Nano(minute) * nanoMinute
+	sx := Nano(second) * nanoSecond
+	return hx + mx + sx + Nano(nanosec)
+}
+
+// NewNanoAt returns a new Nano with the hour, minute, second and nanosecond of a time.
+func NewNanoAt(t time.Time) Nano {
+	hour, minute, second := t.Clock()
+	return NewNano(hour, minute, second, t.Nanosecond())
+}
+
+// NanoSinceMidnight returns a new Nano based on a duration since some arbitrary midnight.
+func NanoSinceMidnight(d time.Duration) Nano {
+	return Nano(d)
+}
+
+// Nano converts a Clock to a Nano. This is always precise.
+func (c Clock) Nano() Nano {
+	return Nano(c) * Nano(time.Millisecond)
+}
+
+// Clock converts a Nano to a Clock, truncating any sub-millisecond part.
+func (n Nano) Clock() Clock {
+	return Clock(n / Nano(time.Millisecond))
+}
+
+// DurationSinceMidnight convert a clock to a time.Duration since some arbitrary midnight.
+func (n Nano) DurationSinceMidnight() time.Duration {
+	return time.Duration(n)
+}
+
+// Add returns a new Nano offset from this clock specified hour, minute, second and nanosecond.
+// The parameters can be negative.
+//
+// If required, use Mod24() to correct any overflow or underflow.
+func (n Nano) Add(h, m, s, ns int) Nano {
+	return n + NewNano(h, m, s, ns)
+}
+
+// AddDuration returns a new Nano offset from this clock by a duration.
+// The parameters can be negative.
+//
+// If required, use Mod24() to correct any overflow or underflow.
+func (n Nano) AddDuration(d time.Duration) Nano {
+	return n + Nano(d)
+}
+
+// ModSubtract returns the duration between two clock times. See Clock.ModSubtract.
+func (n Nano) ModSubtract(n2 Nano) time.Duration {
+	ns := n - n2
+	return ns.Mod24().DurationSinceMidnight()
+}
+
+// IsInOneDay tests whether a clock time is in the range 0 to 24 hours, inclusive.
+// See Clock.IsInOneDay.
+func (n Nano) IsInOneDay() bool {
+	return 0 <= n && n <= NanoDay
+}
+
+// IsMidnight tests whether a clock time is midnight. This is shorthand for n.Mod24() == 0.
+// For large values, this assumes that every day has 24 hours.
+func (n Nano) IsMidnight() bool {
+	return n.Mod24() == 0
+}
+
+// Mod24 calculates the remainder vs 24 hours using Euclidean division, in which the result
+// will be less than 24 hours and is never negative. See Clock.Mod24.
+func (n Nano) Mod24() Nano {
+	m := n % NanoDay
+	if m < 0 {
+		m += NanoDay
+	}
+	return m
+}
+
+// Days gets the number of whole days represented by the Nano, assuming that each day is a fixed
+// 24 hour period. See Clock.Days.
+func (n Nano) Days() int {
+	if n < 0 {
+		return int(n/NanoDay) - 1
+	}
+	return int(n / NanoDay)
+}
+
+// HHMMSS gets the clock-face time as an integer of the form HHMMSS, such as 93015 for
+// 09:30:15. See Clock.HHMMSS.
+func (n Nano) HHMMSS() int {
+	if n == NanoDay {
+		return 240000
+	}
+	nm := n.Mod24()
+	return int(nanoHours(nm)*10000 + nanoMinutes(nm)*100 + nanoSeconds(nm))
+}
+
+// Hours gets the clock-face number of hours (calculated from the modulo time, see Mod24).
+func (n Nano) Hours() int {
+	return int(nanoHours(n.Mod24()))
+}
+
+// Minutes gets the clock-face number of minutes (calculated from the modulo time, see Mod24).
+// For example, for 22:35 this will return 35.
+func (n Nano) Minutes() int {
+	return int(nanoMinutes(n.Mod24()))
+}
+
+// Seconds gets the clock-face number of seconds (calculated from the modulo time, see Mod24).
+// For example, for 10:20:30 this will return 30.
+func (n Nano) Seconds() int {
+	return int(nanoSeconds(n.Mod24()))
+}
+
+// Millisec gets the clock-face number of milliseconds (calculated from the modulo time, see Mod24).
+// For example, for 10:20:30.456789 this will return 456.
+func (n Nano) Millisec() int {
+	return int(nanoFraction(n.Mod24()) / Nano(time.Millisecond))
+}
+
+// Microsec gets the clock-face number of microseconds (calculated from the modulo time, see Mod24).
+// For example, for 10:20:30.456789 this will return 456789.
+func (n Nano) Microsec() int {
+	return int(nanoFraction(n.Mod24()) / Nano(time.Microsecond))
+}
+
+// Nanosec gets the clock-face number of nanoseconds (calculated from the modulo time, see Mod24).
+// For example, for 10:20:30.456789 this will return 456789000.
+func (n Nano) Nanosec() int {
+	return int(nanoFraction(n.Mod24()))
+}
@@ -0,0 +1,216 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNanoHoursMinutesSeconds(t *testing.T) {
+	cases := []struct {
+		in                  Nano
+		h, m, s, ms, us, ns int
+	}{
+		{NewNano(0, 0, 0, 0), 0, 0, 0, 0, 0, 0},
+		{NewNano(1, 2, 3, 4), 1, 2, 3, 0, 0, 4},
+		{NewNano(23, 59, 59, 999999999), 23, 59, 59, 999, 999999, 999999999},
+		{NewNano(0, 0, 0, -1), 23, 59, 59, 999, 999999, 999999999},
+		{NewNanoAt(time.Date(2015, 12, 4, 18, 50, 42, 173444111, time.UTC)), 18, 50, 42, 173, 173444, 173444111},
+		{New(10, 20, 30, 456).Nano(), 10, 20, 30, 456, 456000, 456000000},
+	}
+	for i, x := range cases {
+		h := x.in.Hours()
+		m := x.in.Minutes()
+		s := x.in.Seconds()
+		ms := x.in.Millisec()
+		us := x.in.Microsec()
+		ns := x.in.Nanosec()
+		if h != x.h || m != x.m || s != x.s || ms != x.ms || us != x.us || ns != x.ns {
+			t.Errorf("%d: got %02d:%02d:%02d.%09d (%d, %d), want %v (%d)", i, h, m, s, ns, ms, us, x.in, x.in)
+		}
+	}
+}
+
+func TestNanoClockConversion(t *testing.T) {
+	cases := []Clock{New(0, 0, 0, 0), New(1, 2, 3, 4), New(23, 59, 59, 999), Day, New(-1, 0, 0, 0), New(48, 0, 0, 1)}
+	for i, c := range cases {
+		n := c.Nano()
+		if n.Clock() != c {
+			t.Errorf("%d: got %v, want %v", i, n.Clock(), c)
+		}
+		if n.DurationSinceMidnight() != c.DurationSinceMidnight() {
+			t.Errorf("%d: got %v, want %v", i, n.DurationSinceMidnight(), c.DurationSinceMidnight())
+		}
+	}
+
+	if NewNano(1, 2, 3, 4999999).Clock() != New(1, 2, 3, 4) {
+		t.Errorf("got %v", NewNano(1, 2, 3, 4999999).Clock())
+	}
+
+	if NanoSinceMidnight(90*time.Minute+5) != NewNano(1, 30, 0, 5) {
+		t.Errorf("got %v", NanoSinceMidnight(90*time.Minute+5))
+	}
+}
+
+func TestNanoArithmetic(t *testing.T) {
+	n := NewNano(10, 0, 0, 500)
+
+	if got := n.Add(1, 2, 3, 4); got != NewNano(11, 2, 3, 504) {
+		t.Errorf("got %v", got)
+	}
+	if got := n.Add(0, 0, 0, -501); got != NewNano(9, 59, 59, 999999999) {
+		t.Errorf("got %v", got)
+	}
+	if got := n.AddDuration(time.Microsecond); got != NewNano(10, 0, 0, 1500) {
+		t.Errorf("got %v", got)
+	}
+	if got := NewNano(1, 0, 0, 0).ModSubtract(NewNano(23, 0, 0, 1)); got != 2*time.Hour-1 {
+		t.Errorf("got %v", got)
+	}
+	if got := NewNano(23, 0, 0, 1).ModSubtract(NewNano(1, 0, 0, 0)); got != 22*time.Hour+1 {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestNanoMod24AndDays(t *testing.T) {
+	cases := []struct {
+		in       Nano
+		mod      Nano
+		days     int
+		inOneDay bool
+	}{
+		{0, 0, 0, true},
+		{NanoNoon, NanoNoon, 0, true},
+		{NanoDay, 0, 1, true},
+		{NanoDay + 1, 1, 1, false},
+		{-1, NanoDay - 1, -1, false},
+		{-NanoDay, 0, -2, false},
+		{3*NanoDay + NanoNoon, NanoNoon, 3, false},
+	}
+	for i, x := range cases {
+		if x.in.Mod24() != x.mod {
+			t.Errorf("%d: got %v, want %v", i, x.in.Mod24(), x.mod)
+		}
+		if x.in.Days() != x.days {
+			t.Errorf("%d: got %d, want %d", i, x.in.Days(), x.days)
+		}
+		if x.in.IsInOneDay() != x.inOneDay {
+			t.Errorf("%d: got %v, want %v", i, x.in.IsInOneDay(), x.inOneDay)
+		}
+		if x.in.IsMidnight() != (x.mod == 0) {
+			t.Errorf("%d: got %v", i, x.in.IsMidnight())
+		}
+	}
+}
+
+func TestNanoString(t *testing.T) {
+	cases := []struct {
+		in                                  Nano
+		hh, hhmm, hhmmss, h12, hmmss12, str string
+		frac3, frac6                        string
+		hhmmssInt                           int
+	}{
+		{0, "00", "00:00", "00:00:00", "12am", "12:00:00am", "00:00:00.000000000", "00:00:00.000", "00:00:00.000000", 0},
+		{NewNano(1, 2, 3, 456789), "01", "01:02", "01:02:03", "1am", "1:02:03am", "01:02:03.000456789", "01:02:03.000", "01:02:03.000456", 10203},
+		{NewNano(13, 40, 50, 123456789), "13", "13:40", "13:40:50", "1pm", "1:40:50pm", "13:40:50.123456789", "13:40:50.123", "13:40:50.123456", 134050},
+		{NanoDay, "24", "24:00", "24:00:00", "12am", "12:00:00am", "24:00:00.000000000", "24:00:00.000", "24:00:00.000000", 240000},
+		{-1, "23", "23:59", "23:59:59", "11pm", "11:59:59pm", "23:59:59.999999999", "23:59:59.999", "23:59:59.999999", 235959},
+	}
+	for i, x := range cases {
+		got := []string{x.in.Hh(), x.in.HhMm(), x.in.HhMmSs(), x.in.Hh12(), x.in.HhMmSs12(), x.in.String(),
+			x.in.HhMmSsFraction(3), x.in.HhMmSsFraction(6)}
+		want := []string{x.hh, x.hhmm, x.hhmmss, x.h12, x.hmmss12, x.str, x.frac3, x.frac6}
+		for j := range got {
+			if got[j] != want[j] {
+				t.Errorf("%d.%d: got %q, want %q", i, j, got[j], want[j])
+			}
+		}
+		if x.in.HHMMSS() != x.hhmmssInt {
+			t.Errorf("%d: got %d, want %d", i, x.in.HHMMSS(), x.hhmmssInt)
+		}
+	}
+
+	if s := NewNano(1, 2, 3, 500000000).HhMm12(); s != "1:02am" {
+		t.Errorf("got %q", s)
+	}
+	if s := NewNano(1, 2, 3, 500000000).HhMmSsFraction(0); s != "01:02:03" {
+		t.Errorf("got %q", s)
+	}
+	if s := NewNano(1, 2, 3, 500000000).HhMmSsFraction(12); s != "01:02:03.500000000" {
+		t.Errorf("got %q", s)
+	}
+}
+
+func TestNanoParse(t *testing.T) {
+	cases := []struct {
+		str  string
+		want Nano
+	}{
+		{"00", NewNano(0, 0, 0, 0)},
+		{"0930", NewNano(9, 30, 0, 0)},
+		{"09:30", NewNano(9, 30, 0, 0)},
+		{"093015", NewNano(9, 30, 15, 0)},
+		{"09:30:15", NewNano(9, 30, 15, 0)},
+		{"09:30:15.5", NewNano(9, 30, 15, 500000000)},
+		{"09:30:15.123", NewNano(9, 30, 15, 123000000)},
+		{"09:30:15.123456", NewNano(9, 30, 15, 123456000)},
+		{"09:30:15.123456789", NewNano(9, 30, 15, 123456789)},
+		{"09:30:15.1234567891", NewNano(9, 30, 15, 123456789)},
+		{"24:00:00.000000", NanoDay},
+		{"2pm", NewNano(14, 0, 0, 0)},
+		{"2:45:30.000001pm", NewNano(14, 45, 30, 1000)},
+		{"12:00:00.5am", NewNano(0, 0, 0, 500000000)},
+		{"10.5", NewNano(10, 30, 0, 0)},
+		{"10:15.000001", NewNano(10, 15, 0, 60000)},
+		{"T09:30:15,123456789+02", NewNano(9, 30, 15, 123456789)},
+	}
+	for i, x := range cases {
+		n, err := ParseNano(x.str)
+		if err != nil {
+			t.Errorf("%d: %s: %v", i, x.str, err)
+		} else if n != x.want {
+			t.Errorf("%d: %s: got %v, want %v", i, x.str, n, x.want)
+		}
+		if err == nil && MustParseNano(x.str) != n {
+			t.Errorf("%d: %s: MustParseNano got %v", i, x.str, MustParseNano(x.str))
+		}
+	}
+
+	round := NewNano(9, 30, 15, 123456789)
+	if n := MustParseNano(round.String()); n != round {
+		t.Errorf("got %v, want %v", n, round)
+	}
+}
+
+func TestNanoParseSQL(t *testing.T) {
+	cases := []struct {
+		str  string
+		want Nano
+	}{
+		{"10:15", NewNano(10, 15, 0, 0)},
+		{"10:15:30.123456", NewNano(10, 15, 30, 123456000)},
+		{"100:59:59.000001", NewNano(100, 59, 59, 1000)},
+		{" 10:00:00.25+02 ", NewNano(10, 0, 0, 250000000)},
+		{"10:00:00.000000001-05:30", NewNano(10, 0, 0, 1)},
+	}
+	for i, x := range cases {
+		n, err := ParseNanoSQL(x.str)
+		if err != nil {
+			t.Errorf("%d: %s: %v", i, x.str, err)
+		} else if n != x.want {
+			t.Errorf("%d: %s: got %v, want %v", i, x.str, n, x.want)
+		}
+	}
+}
+
+func TestNanoParseBads(t *testing.T) {
+	cases := []string{"", "0", "09:30:15.", "09:30:15.x", "09:30:15.5.5", "10:15:30.123456+2", "not-a-clock"}
+	for i, s := range cases {
+		if n, err := ParseNano(s); err == nil {
+			t.Errorf("%d: %s: got %v", i, s, n)
+		}
+	}
+}
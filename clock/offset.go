@@ -0,0 +1,116 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"fmt"
+	"time"
+)
+
+// OffsetClock specifies a time of day along with a fixed offset from UTC. This corresponds
+// to the SQL TIME WITH TIME ZONE type (e.g. PostgreSQL "timetz"), for which the zone is
+// just an offset rather than a location with daylight-saving rules.
+//
+// OffsetClock values can be compared using == and !=. Note that this compares both
+// the clock and the offset, so 10:00+02:00 is not equal to 08:00Z, even though they
+// refer to the same instant on any given day. Use UTC to compare the instants instead.
+type OffsetClock struct {
+	clock  Clock
+	offset int32 // seconds east of UTC
+}
+
+// NewOffsetClock returns a new OffsetClock with specified clock time and offset,
+// in seconds east of UTC.
+func NewOffsetClock(c Clock, offsetSeconds int) OffsetClock {
+	return OffsetClock{c, int32(offsetSeconds)}
+}
+
+// NewOffsetClockAt returns a new OffsetClock with the clock time and offset of a given time.
+func NewOffsetClockAt(t time.Time) OffsetClock {
+	_, offset := t.Zone()
+	return OffsetClock{NewAt(t), int32(offset)}
+}
+
+// MustParseOffsetClock is as per ParseOffsetClock except that it panics if the string
+// cannot be parsed. This is intended for setup code; don't use it for user inputs.
+func MustParseOffsetClock(value string) OffsetClock {
+	oc, err := ParseOffsetClock(value)
+	if err != nil {
+		panic(err)
+	}
+	return oc
+}
+
+// ParseOffsetClock converts a string representation of an SQL TIME WITH TIME ZONE value
+// to an OffsetClock. The clock part is as per ParseSQL; the offset is "Z" or a sign
+// followed by hours, and optionally minutes and seconds, e.g. "10:00:00+02",
+// "10:00:00.123456-05:30", "10:00Z". If the offset is absent, UTC is assumed.
+func ParseOffsetClock(value string) (OffsetClock, error) {
+	c, offset, _, err := parseSQL(value)
+	if err != nil {
+		return OffsetClock{}, err
+	}
+	return OffsetClock{c, int32(offset)}, nil
+}
+
+// Clock returns the clock time, which is relative to the offset.
+func (oc OffsetClock) Clock() Clock {
+	return oc.clock
+}
+
+// Offset returns the offset in seconds east of UTC.
+func (oc OffsetClock) Offset() int {
+	return int(oc.offset)
+}
+
+// Location returns a fixed-zone location for the offset.
+func (oc OffsetClock) Location() *time.Location {
+	if oc.offset == 0 {
+		return time.UTC
+	}
+	return time.FixedZone(formatOffset(oc.offset), int(oc.offset))
+}
+
+// UTC returns the equivalent clock time in UTC. The result is always in the range
+// from midnight to just before 24:00 (see Mod24).
+func (oc OffsetClock) UTC() Clock {
+	return (oc.clock - Clock(oc.offset)*Second).Mod24()
+}
+
+// In returns the equivalent OffsetClock for a different offset, in seconds east of UTC.
+// The result is always in the range from midnight to just before 24:00 (see Mod24).
+func (oc OffsetClock) In(offsetSeconds int) OffsetClock {
+	c := oc.UTC() + Clock(offsetSeconds)*Second
+	return OffsetClock{c.Mod24(), int32(offsetSeconds)}
+}
+
+// On returns the time.Time for the clock time on a specified date, in the fixed zone
+// given by the offset.
+func (oc OffsetClock) On(year int, month time.Month, day int) time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, oc.Location())
+	return t.Add(oc.clock.DurationSinceMidnight())
+}
+
+// String gets the clock time as per Clock.String, followed by the offset in the
+// form "+hh:mm", e.g. "10:00:00.000+02:00". Offsets with a number of seconds are
+// given as "+hh:mm:ss".
+func (oc OffsetClock) String() string {
+	return oc.clock.String() + formatOffset(oc.offset)
+}
+
+func formatOffset(offset int32) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	h := offset / 3600
+	m := (offset % 3600) / 60
+	s := offset % 60
+	if s != 0 {
+		return fmt.Sprintf("%c%02d:%02d:%02d", sign, h, m, s)
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, h, m)
+}
@@ -0,0 +1,92 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseOffsetClock(t *testing.T) {
+	cases := []struct {
+		str    string
+		clock  Clock
+		offset int
+		utc    Clock
+	}{
+		{"10:00:00", New(10, 0, 0, 0), 0, New(10, 0, 0, 0)},
+		{"10:00:00Z", New(10, 0, 0, 0), 0, New(10, 0, 0, 0)},
+		{"10:00:00+02", New(10, 0, 0, 0), 7200, New(8, 0, 0, 0)},
+		{"10:00:00+0230", New(10, 0, 0, 0), 9000, New(7, 30, 0, 0)},
+		{"01:00:00.5+02:00", New(1, 0, 0, 500), 7200, New(23, 0, 0, 500)},
+		{"23:00:00-05:00", New(23, 0, 0, 0), -18000, New(4, 0, 0, 0)},
+		{"12:00:00-00:17:30", New(12, 0, 0, 0), -1050, New(12, 17, 30, 0)},
+	}
+	for _, x := range cases {
+		oc, err := ParseOffsetClock(x.str)
+		if err != nil {
+			t.Errorf("%s, got %v", x.str, err)
+			continue
+		}
+		if oc.Clock() != x.clock || oc.Offset() != x.offset {
+			t.Errorf("%s, got %v %d, want %v %d", x.str, oc.Clock(), oc.Offset(), x.clock, x.offset)
+		}
+		if oc.UTC() != x.utc {
+			t.Errorf("%s, got %v, want %v", x.str, oc.UTC(), x.utc)
+		}
+		if oc.In(0).Clock() != x.utc {
+			t.Errorf("%s, got %v, want %v", x.str, oc.In(0), x.utc)
+		}
+		_, offset := oc.On(2020, time.June, 1).Zone()
+		if offset != x.offset {
+			t.Errorf("%s, got %d, want %d", x.str, offset, x.offset)
+		}
+	}
+}
+
+func TestParseOffsetClockErrors(t *testing.T) {
+	cases := []struct {
+		str, want string
+	}{
+		{"10:00:00+02:60", "clock.Clock: cannot parse 10:00:00+02:60: offset minute out of range"},
+		{"10:00:00-0099", "clock.Clock: cannot parse 10:00:00-0099: offset minute out of range"},
+		{"10:00:00+25", "clock.Clock: cannot parse 10:00:00+25: offset hour out of range"},
+		{"10:00:00+01:00:75", "clock.Clock: cannot parse 10:00:00+01:00:75: offset second out of range"},
+		{"10:00:00.5x+02", "clock.Clock: cannot parse 10:00:00.5x+02"},
+	}
+	for _, x := range cases {
+		_, err := ParseOffsetClock(x.str)
+		if err == nil || err.Error() != x.want {
+			t.Errorf("%s, got %v, want %s", x.str, err, x.want)
+		}
+	}
+}
+
+func TestOffsetClockOn(t *testing.T) {
+	oc := MustParseOffsetClock("10:15:00+02")
+	got := oc.On(2020, time.June, 1)
+	want := time.Date(2020, time.June, 1, 8, 15, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestOffsetClockString(t *testing.T) {
+	cases := []struct {
+		in   OffsetClock
+		want string
+	}{
+		{NewOffsetClock(New(10, 0, 0, 0), 0), "10:00:00.000+00:00"},
+		{NewOffsetClock(New(10, 0, 0, 0), 3600), "10:00:00.000+01:00"},
+		{NewOffsetClock(New(10, 0, 0, 0), -16200), "10:00:00.000-04:30"},
+		{NewOffsetClock(New(10, 0, 0, 0), -1050), "10:00:00.000-00:17:30"},
+		{NewOffsetClock(Day, 0), "24:00:00.000+00:00"},
+	}
+	for _, x := range cases {
+		if x.in.String() != x.want {
+			t.Errorf("got %v, want %v", x.in.String(), x.want)
+		}
+	}
+}
@@ -6,11 +6,40 @@ package clock
 
 import (
 	"fmt"
-	"runtime"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
+// ParseError describes a string that cannot be parsed as a clock time.
+type ParseError struct {
+	Type   string // the type being parsed, e.g. "clock.Clock"
+	Value  string // the string that was parsed
+	Layout string // the layout, if any (see ParseLayout)
+	Reason string // a description of the problem, if known
+	Err    error  // the underlying error, if any
+}
+
+func (e *ParseError) Error() string {
+	s := fmt.Sprintf("%s: cannot parse %s", e.Type, e.Value)
+	if e.Layout != "" {
+		s = fmt.Sprintf("%s: cannot parse %q as %q", e.Type, e.Value, e.Layout)
+	}
+	if e.Reason != "" {
+		s += ": " + e.Reason
+	}
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+// Unwrap returns the underlying error, if any.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
 // MustParse is as per Parse except that it panics if the string cannot be parsed.
 // This is intended for setup code; don't use it for user inputs.
 func MustParse(hms string) Clock {
@@ -22,111 +51,420 @@ func MustParse(hms string) Clock {
 }
 
 // Parse converts a string representation to a Clock. Acceptable representations
-// are as per ISO-8601 - see https://en.wikipedia.org/wiki/ISO_8601#Times
+// are as per ISO-8601 (see ParseISO) and the conventional written forms accepted by
+// ParseHuman, such as "2am", "2:45pm", "9h30" and "noon". Remember that 12am is
+// midnight and 12pm is noon.
+//
+// Strings containing letters (other than a leading "T" or a trailing "Z") are parsed
+// by ParseHuman, whereas all others are parsed as per ParseISO. So "10.30" is ten and three
+// tenths of an hour, i.e. 10:18, whereas "10.30am" is 10:30.
 //
-// Also, conventional AM- and PM-based strings are parsed, such as "2am", "2:45pm".
-// Remember that 12am is midnight and 12pm is noon.
+// Unlike ParseISO, the hours, minutes and seconds are not range-checked, so times past
+// midnight such as "26:30" are allowed, and "10:60" is the same as "11:00".
+//
+// The error, if any, is a *ParseError.
 func Parse(hms string) (clock Clock, err error) {
-	if strings.HasSuffix(hms, "am") || strings.HasSuffix(hms, "AM") {
-		return parseAmPm(hms, 0)
-	} else if strings.HasSuffix(hms, "pm") || strings.HasSuffix(hms, "PM") {
-		return parseAmPm(hms, 12)
+	d, err := parseClock(hms, "clock.Clock")
+	if err != nil {
+		return 0, err
+	}
+	return SinceMidnight(d), nil
+}
+
+// ParseISO converts an ISO-8601 time string to a Clock. This is strict and does not accept
+// the written forms understood by Parse. The time may be in the basic form "hh", "hhmm" or
+// "hhmmss", or the extended form "hh:mm" or "hh:mm:ss", optionally preceded by the "T"
+// designator. The last component can have a decimal fraction of any length, using either
+// a point or a comma, e.g. "10.5" (10:30), "10:15.5" (10:15:30) or "10:15:30,25";
+// the result is truncated to the nearest millisecond. A trailing "Z" or UTC offset such
+// as "+02", "-0530" or "+05:30" is accepted but discarded, so the result is the local
+// wall-clock time as written. The hours are in the range 00 to 24; "24:00" is Day.
+// The minutes and seconds are in the range 00 to 59.
+//
+// The error, if any, is a *ParseError.
+func ParseISO(hms string) (Clock, error) {
+	d, err := parseISO(hms, "clock.Clock", true)
+	if err != nil {
+		return 0, err
+	}
+	return SinceMidnight(d), nil
+}
+
+// ParseHuman converts a time written the way people commonly write it to a Clock. This
+// is lenient and accepts
+//
+//   - twelve-hour times with "am" or "pm" (also "a.m." or "p.m."), in either case and
+//     optionally preceded by a space, e.g. "2pm", "2:45 PM", "9.30am", "11:15:30.5pm";
+//   - hours and minutes separated by "h", e.g. "9h", "9h30", "21h05";
+//   - the words "noon", "midday" and "midnight".
+//
+// The error, if any, is a *ParseError.
+func ParseHuman(hms string) (Clock, error) {
+	d, err := parseHuman(hms, "clock.Clock")
+	if err != nil {
+		return 0, err
+	}
+	return SinceMidnight(d), nil
+}
+
+func parseClock(hms, typ string) (time.Duration, error) {
+	if isHuman(hms) {
+		return parseHuman(hms, typ)
 	}
-	return parseISO(hms)
+	return parseISO(hms, typ, false)
 }
 
-func parseISO(hms string) (clock Clock, err error) {
-	switch len(hms) {
-	case 2: // HH
-		return parseClockParts(hms, hms, "", "", "", 0, 0)
+// isHuman tests whether a string contains letters, apart from the designators of ISO-8601.
+func isHuman(hms string) bool {
+	s := strings.TrimPrefix(strings.TrimPrefix(hms, "T"), "t")
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "Z"), "z")
+	return strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsSpace(r)
+	}) >= 0
+}
 
-	case 4: // HHMM
-		return parseClockParts(hms, hms[:2], hms[2:], "", "", 0, 0)
+// parseISO parses "[T]hh[[:]mm[[:]ss]][(.|,)fff...][offset]", in which the fraction
+// applies to the last component. Only when strict are the fields range-checked.
+func parseISO(value, typ string, strict bool) (time.Duration, error) {
+	hms := value
+	if hms != "" && (hms[0] == 'T' || hms[0] == 't') {
+		hms = hms[1:]
+	}
 
-	case 5: // HH:MM
-		if hms[2] != ':' {
-			return 0, parseError(hms, nil)
+	if z := strings.IndexAny(hms, "+-Zz"); z >= 0 {
+		if z == 0 || !isOffset(hms[z:]) {
+			return 0, newParseError(typ, value, "invalid UTC offset")
 		}
-		return parseClockParts(hms, hms[:2], hms[3:], "", "", 0, 0)
+		hms = hms[:z]
+	}
 
-	case 6: // HHMMSS
-		return parseClockParts(hms, hms[:2], hms[2:4], hms[4:], "", 0, 0)
+	fraction := ""
+	hasFraction := false
+	if dot := strings.IndexAny(hms, ".,"); dot >= 0 {
+		fraction = hms[dot+1:]
+		hms = hms[:dot]
+		hasFraction = true
+		if fraction == "" || !allDigits(fraction) {
+			return 0, newParseError(typ, value, "invalid fraction")
+		}
+	}
 
-	case 8: // HH:MM:SS
-		if hms[2] != ':' || hms[5] != ':' {
-			return 0, parseError(hms, nil)
+	var parts []string
+	if strings.IndexByte(hms, ':') >= 0 {
+		parts = strings.Split(hms, ":")
+	} else {
+		for i := 0; i < len(hms); i += 2 {
+			parts = append(parts, hms[i:minInt(i+2, len(hms))])
 		}
-		return parseClockParts(hms, hms[:2], hms[3:5], hms[6:], "", 0, 0)
+	}
+
+	if len(parts) == 0 || len(parts) > 3 {
+		return 0, newParseError(typ, value, "incorrect syntax")
+	}
 
-	case 9, 10: // HH:MM:SS.0
-		if hms[2] != ':' || hms[5] != ':' || hms[8] != '.' {
-			return 0, parseError(hms, nil)
+	fields := make([]int, 3)
+	for i, p := range parts {
+		if len(p) != 2 || !allDigits(p) {
+			return 0, newParseError(typ, value, "incorrect syntax")
 		}
-		return parseClockParts(hms, hms[:2], hms[3:5], hms[6:8], hms[9:]+"00", 0, 0)
+		fields[i], _ = strconv.Atoi(p)
+	}
 
-	case 11: // HH:MM:SS.00
-		if hms[2] != ':' || hms[5] != ':' || hms[8] != '.' {
-			return 0, parseError(hms, nil)
+	h, m, s := fields[0], fields[1], fields[2]
+	if strict && h > 24 {
+		return 0, newParseError(typ, value, "hour out of range")
+	}
+	if strict && m > 59 {
+		return 0, newParseError(typ, value, "minute out of range")
+	}
+	if strict && s > 59 {
+		return 0, newParseError(typ, value, "second out of range")
+	}
+
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	if hasFraction {
+		d += fractionOf(fraction, units[len(parts)-1])
+	}
+
+	if strict && h == 24 && d != 24*time.Hour {
+		return 0, newParseError(typ, value, "hour out of range")
+	}
+	return d, nil
+}
+
+// isOffset tests whether a string is "Z" or a sign followed by "hh", "hhmm" or "hh:mm".
+func isOffset(zone string) bool {
+	if zone == "Z" || zone == "z" {
+		return true
+	}
+	if zone[0] != '+' && zone[0] != '-' {
+		return false
+	}
+	switch digits := zone[1:]; len(digits) {
+	case 2, 4:
+		return allDigits(digits)
+	case 5:
+		return digits[2] == ':' && allDigits(digits[:2]) && allDigits(digits[3:])
+	}
+	return false
+}
+
+// parseHuman parses the written forms described by ParseHuman.
+func parseHuman(value, typ string) (time.Duration, error) {
+	hms := strings.ToLower(strings.TrimSpace(value))
+
+	switch hms {
+	case "noon", "midday":
+		return 12 * time.Hour, nil
+	case "midnight":
+		return 0, nil
+	}
+
+	for _, sfx := range []string{"am", "a.m.", "pm", "p.m."} {
+		if strings.HasSuffix(hms, sfx) {
+			pm := sfx[0] == 'p'
+			return parseAmPm(value, typ, strings.TrimSpace(hms[:len(hms)-len(sfx)]), pm)
 		}
-		return parseClockParts(hms, hms[:2], hms[3:5], hms[6:8], hms[9:]+"0", 0, 0)
+	}
+
+	if h := strings.IndexByte(hms, 'h'); h > 0 {
+		return parseHoursH(value, typ, hms[:h], hms[h+1:])
+	}
+
+	return 0, newParseError(typ, value, "unrecognised time")
+}
 
-	case 12: // HH:MM:SS.000
-		if hms[2] != ':' || hms[5] != ':' || hms[8] != '.' {
-			return 0, parseError(hms, nil)
+// parseAmPm parses "h[(:|.)mm[:ss[(.|,)fff...]]]" for twelve-hour times.
+func parseAmPm(value, typ, hms string, pm bool) (time.Duration, error) {
+	hh, rest := leadingDigits(hms)
+	if len(hh) == 0 || len(hh) > 2 {
+		return 0, newParseError(typ, value, "incorrect syntax")
+	}
+
+	h, _ := strconv.Atoi(hh)
+	if h > 12 {
+		return 0, newParseError(typ, value, "hour out of range")
+	}
+
+	d := time.Duration(h%12) * time.Hour
+	if pm {
+		d += 12 * time.Hour
+	}
+
+	// the minutes can follow a colon or a point, the seconds only a colon
+	fields := 0
+	for _, unit := range []time.Duration{time.Minute, time.Second} {
+		if rest == "" || (rest[0] != ':' && (rest[0] != '.' || unit != time.Minute)) {
+			break
+		}
+		var n int
+		n, rest = atoi2(rest[1:])
+		if n < 0 {
+			return 0, newParseError(typ, value, "incorrect syntax")
+		}
+		if n > 59 {
+			return 0, newParseError(typ, value, "minute or second out of range")
+		}
+		d += time.Duration(n) * unit
+		fields++
+	}
+
+	// a fraction of a second can only follow the seconds, and may be empty
+	if rest != "" && (rest[0] == '.' || rest[0] == ',') && fields == 2 {
+		digits := rest[1:]
+		if !allDigits(digits) {
+			return 0, newParseError(typ, value, "invalid fraction")
 		}
-		return parseClockParts(hms, hms[:2], hms[3:5], hms[6:8], hms[9:], 0, 0)
+		d += fractionOf(digits, time.Second)
+		rest = ""
 	}
-	return 0, parseError(hms, nil)
+
+	if rest != "" {
+		return 0, newParseError(typ, value, "incorrect syntax")
+	}
+	return d, nil
 }
 
-func parseAmPm(hms string, offset int) (clock Clock, err error) {
-	n := len(hms)
+// parseHoursH parses "h" or "hh", then "h" (already removed), then optionally "mm".
+func parseHoursH(value, typ, hh, mm string) (time.Duration, error) {
+	if len(hh) > 2 || !allDigits(hh) {
+		return 0, newParseError(typ, value, "incorrect syntax")
+	}
+	h, _ := strconv.Atoi(hh)
 
-	switch len(hms) {
-	case 3: // Ham
-		return parseClockParts(hms, "0"+hms[:1], "", "", "", 12, offset)
+	m := 0
+	if mm != "" {
+		var rest string
+		m, rest = atoi2(mm)
+		if m < 0 || rest != "" {
+			return 0, newParseError(typ, value, "incorrect syntax")
+		}
+		if m > 59 {
+			return 0, newParseError(typ, value, "minute out of range")
+		}
+	}
 
-	case 4: // HHam
-		return parseClockParts(hms, hms[:2], "", "", "", 12, offset)
+	if h > 24 || (h == 24 && m > 0) {
+		return 0, newParseError(typ, value, "hour out of range")
 	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// leadingDigits splits a string after its leading decimal digits.
+func leadingDigits(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
 
-	colon := strings.IndexByte(hms, ':')
-	if colon < 0 {
-		return 0, parseError(hms, nil)
+// atoi2 parses exactly two leading digits, returning -1 if they are absent.
+func atoi2(s string) (int, string) {
+	if len(s) < 2 || !isDigit(s[0]) || !isDigit(s[1]) {
+		return -1, s
 	}
+	return int(s[0]-'0')*10 + int(s[1]-'0'), s[2:]
+}
 
-	h := hms[:colon]
-	rest := hms[colon+1 : n-2]
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
 
-	switch len(rest) {
-	case 2: // MM
-		return parseClockParts(hms, h, rest, "", "", 12, offset)
+// fractionOf converts the digits of a decimal fraction to that fraction of a unit.
+// Digits beyond nanoseconds are truncated.
+func fractionOf(digits string, unit time.Duration) time.Duration {
+	if len(digits) > 9 {
+		digits = digits[:9]
+	}
+	n, _ := strconv.Atoi((digits + "000000000")[:9])
+	return time.Duration(n) * (unit / time.Second)
+}
+
+// ParseSQL converts a string representation of an SQL TIME value to a Clock. This is
+// the form produced by databases such as PostgreSQL and MySQL, i.e. "HH:MM:SS" with an
+// optional fraction of any length (e.g. "10:15:30.123456"); the fraction is truncated
+// to the nearest millisecond. The hours may exceed 24 (as for MySQL TIME, e.g. "838:59:59"),
+// and "24:00:00" gives the Day value. The minutes and seconds must be less than 60.
+// Negative values (e.g. MySQL's "-01:00:00") are not supported.
+//
+// Values of SQL TIME WITH TIME ZONE (e.g. "10:00:00+02") are also accepted, but the offset
+// is discarded so the result is the local wall-clock time as written. Use ParseOffsetClock
+// to retain the offset.
+func ParseSQL(hms string) (clock Clock, err error) {
+	clock, _, _, err = parseSQL(hms)
+	return clock, err
+}
+
+// parseSQL parses "HH:MM[:SS[.fff...]][offset]", where the optional offset is "Z"
+// or a sign followed by "HH", "HHMM", "HH:MM" or "HH:MM:SS".
+func parseSQL(value string) (clock Clock, offset int, hasOffset bool, err error) {
+	hms := strings.TrimSpace(value)
+
+	if strings.HasPrefix(hms, "-") {
+		return 0, 0, false, newParseError("clock.Clock", value, "negative times are not supported")
+	}
+
+	z := strings.IndexAny(hms, "+-Zz")
+	if z == 0 {
+		return 0, 0, false, parseError(value, nil)
+	}
 
-	case 5: // MM:SS
-		if rest[2] != ':' {
-			return 0, parseError(hms, nil)
+	if z > 0 {
+		offset, err = parseOffset(value, hms[z:])
+		if err != nil {
+			return 0, 0, false, err
 		}
-		return parseClockParts(hms, h, rest[:2], rest[3:], "", 12, offset)
+		hms = hms[:z]
+		hasOffset = true
+	}
+
+	c1 := strings.IndexByte(hms, ':')
+	if c1 < 2 || len(hms) < c1+3 {
+		return 0, 0, false, parseError(value, nil)
+	}
+
+	hh := hms[:c1]
+	mm := hms[c1+1 : c1+3]
+	ss := ""
+	fff := ""
+	rest := hms[c1+3:]
 
-	case 6, 7: // MM:SS.0xm
-		if rest[2] != ':' || rest[5] != '.' {
-			return 0, parseError(hms, nil)
+	if len(rest) > 0 {
+		if len(rest) < 3 || rest[0] != ':' {
+			return 0, 0, false, parseError(value, nil)
 		}
-		return parseClockParts(hms, h, rest[:2], rest[3:5], rest[6:]+"00", 12, offset)
+		ss = rest[1:3]
+		rest = rest[3:]
+	}
 
-	case 8: // MM:SS.00xm
-		if rest[2] != ':' || rest[5] != '.' {
-			return 0, parseError(hms, nil)
+	if len(rest) > 0 {
+		if rest[0] != '.' || len(rest) == 1 || !allDigits(rest[1:]) {
+			return 0, 0, false, parseError(value, nil)
 		}
-		return parseClockParts(hms, h, rest[:2], rest[3:5], rest[6:]+"0", 12, offset)
+		fff = fraction3(rest[1:])
+	}
+
+	if n, _ := atoi2(mm); n > 59 {
+		return 0, 0, false, newParseError("clock.Clock", value, "minute out of range")
+	}
+	if n, _ := atoi2(ss); n > 59 {
+		return 0, 0, false, newParseError("clock.Clock", value, "second out of range")
+	}
+
+	clock, err = parseClockParts(value, hh, mm, ss, fff, 0, 0)
+	return clock, offset, hasOffset, err
+}
+
+// fraction3 converts a decimal fraction of any length to exactly three digits,
+// i.e. milliseconds. Excess digits are truncated, so they must already have been
+// checked.
+func fraction3(digits string) string {
+	if len(digits) >= 3 {
+		return digits[:3]
+	}
+	return (digits + "00")[:3]
+}
+
+// parseOffset parses a UTC offset, returning the number of seconds east of UTC.
+func parseOffset(value, zone string) (int, error) {
+	if zone == "Z" || zone == "z" {
+		return 0, nil
+	}
+
+	sign := 1
+	if zone[0] == '-' {
+		sign = -1
+	}
+
+	digits := strings.Replace(zone[1:], ":", "", -1)
+	if len(digits) != 2 && len(digits) != 4 && len(digits) != 6 || !allDigits(digits) {
+		return 0, parseError(value, nil)
+	}
 
-	case 9: // MM:SS.000xm
-		if rest[2] != ':' || rest[5] != '.' {
-			return 0, parseError(hms, nil)
+	secs := 0
+	for _, f := range []struct {
+		unit, max int
+		what      string
+	}{{3600, 23, "offset hour"}, {60, 59, "offset minute"}, {1, 59, "offset second"}} {
+		if len(digits) == 0 {
+			break
 		}
-		return parseClockParts(hms, h, rest[:2], rest[3:5], rest[6:], 12, offset)
+		var n int
+		n, digits = atoi2(digits)
+		if n > f.max {
+			return 0, newParseError("clock.Clock", value, f.what+" out of range")
+		}
+		secs += n * f.unit
 	}
-	return 0, parseError(hms, nil)
+
+	return sign * secs, nil
 }
 
 func parseClockParts(hms, hh, mm, ss, mmms string, mod, offset int) (clock Clock, err error) {
@@ -165,9 +503,79 @@ func parseClockParts(hms, hh, mm, ss, mmms string, mod, offset int) (clock Clock
 }
 
 func parseError(hms string, err error) error {
-	_, _, line, _ := runtime.Caller(1)
+	return &ParseError{Type: "clock.Clock", Value: hms, Err: err}
+}
+
+func newParseError(typ, hms, reason string) error {
+	return &ParseError{Type: typ, Value: hms, Reason: reason}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// MustParseNano is as per ParseNano except that it panics if the string cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustParseNano(hms string) Nano {
+	n, err := ParseNano(hms)
 	if err != nil {
-		return fmt.Errorf("parse.go:%d: clock.Clock: cannot parse %s: %v", line, hms, err)
+		panic(err)
 	}
-	return fmt.Errorf("parse.go:%d: clock.Clock: cannot parse %s", line, hms)
+	return n
+}
+
+// ParseNano converts a string representation to a Nano. The representations are the
+// same as for Parse, except that the fraction is kept to the nearest nanosecond
+// (e.g. "10:15:30.123456" or "2:45:30.5pm"). Digits beyond nanoseconds are truncated.
+func ParseNano(hms string) (Nano, error) {
+	d, err := parseClock(hms, "clock.Nano")
+	if err != nil {
+		return 0, err
+	}
+	return NanoSinceMidnight(d), nil
+}
+
+// ParseNanoSQL converts a string representation of an SQL TIME value to a Nano, as per
+// ParseSQL except that the fraction is kept to the nearest nanosecond.
+func ParseNanoSQL(hms string) (Nano, error) {
+	return parseNano(strings.TrimSpace(hms), ParseSQL)
+}
+
+// parseNano removes the fraction of a second and parses the rest as a Clock.
+func parseNano(hms string, parseClock func(string) (Clock, error)) (Nano, error) {
+	dot := strings.IndexByte(hms, '.')
+	if dot < 0 {
+		c, err := parseClock(hms)
+		if err != nil {
+			return 0, parseNanoError(hms, nil)
+		}
+		return c.Nano(), nil
+	}
+
+	end := dot + 1
+	for end < len(hms) && '0' <= hms[end] && hms[end] <= '9' {
+		end++
+	}
+
+	digits := hms[dot+1 : end]
+	if digits == "" || strings.IndexByte(hms[end:], '.') >= 0 {
+		return 0, parseNanoError(hms, nil)
+	}
+
+	c, err := parseClock(hms[:dot] + hms[end:])
+	if err != nil {
+		return 0, parseNanoError(hms, nil)
+	}
+
+	if len(digits) > 9 {
+		digits = digits[:9]
+	}
+	ns, err := strconv.Atoi((digits + "00000000")[:9])
+	if err != nil {
+		return 0, parseNanoError(hms, err)
+	}
+
+	return c.Nano() + Nano(ns), nil
+}
+
+func parseNanoError(hms string, err error) error {
+	return &ParseError{Type: "clock.Nano", Value: hms, Err: err}
 }
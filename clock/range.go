@@ -0,0 +1,170 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"strings"
+	"time"
+)
+
+// Range is a range of times of day, from a start clock time up to (but excluding) an end
+// clock time. The end can be before the start, in which case the range wraps past midnight;
+// for example, a night shift from 22:00 to 06:00.
+//
+// Both ends are held as modulo times (see Mod24), except that an end of 24:00 is kept so
+// that ranges up to the end of the day can be expressed. So 00:00-24:00 is the whole day,
+// whereas a range whose start and end are the same is empty.
+//
+// Range values can be compared using == and !=.
+type Range struct {
+	start, end Clock
+}
+
+// NewRange returns a new Range from a start clock time to an end clock time. Note that
+// a start and end that are a whole day apart give an empty range, except for 00:00-24:00.
+func NewRange(start, end Clock) Range {
+	if end != Day {
+		end = end.Mod24()
+	}
+	return Range{start.Mod24(), end}
+}
+
+// Start returns the start of the range, which is included in it.
+func (r Range) Start() Clock {
+	return r.start
+}
+
+// End returns the end of the range, which is excluded from it.
+func (r Range) End() Clock {
+	return r.end
+}
+
+// IsEmpty tests whether the range contains no times, i.e. its start and end are the same.
+func (r Range) IsEmpty() bool {
+	return r.start == r.end
+}
+
+// Wraps tests whether the range crosses midnight, i.e. its end is before its start.
+func (r Range) Wraps() bool {
+	return r.end < r.start
+}
+
+// Duration returns the length of the range, which is between zero and 24 hours.
+func (r Range) Duration() time.Duration {
+	if r.end == Day {
+		return (Day - r.start).DurationSinceMidnight()
+	}
+	return r.end.ModSubtract(r.start)
+}
+
+// Contains tests whether a clock time is within the range. The clock time is first
+// reduced to its modulo time (see Mod24).
+func (r Range) Contains(c Clock) bool {
+	c = c.Mod24()
+	if r.Wraps() {
+		return r.start <= c || c < r.end
+	}
+	return r.start <= c && c < r.end
+}
+
+// Overlaps tests whether two ranges have any times in common.
+func (r Range) Overlaps(other Range) bool {
+	return len(r.Intersect(other)) > 0
+}
+
+// Intersect returns the times that two ranges have in common. This is a slice because
+// two ranges that both wrap past midnight can have two separate parts in common, e.g.
+// 22:00-06:00 and 05:00-23:00 give 05:00-06:00 and 22:00-23:00. The result is empty if
+// the ranges don't overlap; otherwise it is ordered by start time.
+func (r Range) Intersect(other Range) []Range {
+	var parts []Range
+	for _, a := range r.Split() {
+		for _, b := range other.Split() {
+			start, end := a.start, a.end
+			if b.start > start {
+				start = b.start
+			}
+			if b.end < end {
+				end = b.end
+			}
+			if start < end {
+				parts = append(parts, Range{start, end})
+			}
+		}
+	}
+
+	// rejoin any part that runs to midnight with one that starts at midnight
+	if n := len(parts); n > 1 && parts[0].start == Midnight && parts[n-1].end == Day {
+		parts[n-1].end = parts[0].end
+		parts = parts[1:]
+	}
+	return parts
+}
+
+// Split divides a range that wraps past midnight into its evening and morning parts,
+// so 22:00-06:00 gives 00:00-06:00 and 22:00-24:00. A range that does not wrap is
+// returned unchanged, and an empty range gives an empty result. The parts are ordered
+// by start time.
+func (r Range) Split() []Range {
+	switch {
+	case r.IsEmpty():
+		return nil
+	case r.Wraps() && r.end == Midnight:
+		return []Range{{r.start, Day}}
+	case r.Wraps():
+		return []Range{{Midnight, r.end}, {r.start, Day}}
+	}
+	return []Range{r}
+}
+
+// String gets the range as two clock times separated by a hyphen, e.g. "09:00-17:30".
+// The clock times are given as "hh:mm" unless seconds or milliseconds are needed.
+func (r Range) String() string {
+	format := Clock.HhMm
+	if r.start%Minute != 0 || r.end%Minute != 0 {
+		format = Clock.HhMmSs
+	}
+	if r.start%Second != 0 || r.end%Second != 0 {
+		format = Clock.String
+	}
+	return format(r.start) + "-" + format(r.end)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// MustParseRange is as per ParseRange except that it panics if the string cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustParseRange(value string) Range {
+	r, err := ParseRange(value)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// ParseRange converts a string representation to a Range. This is two clock times
+// separated by a hyphen, such as "09:00-17:30" or "22:00-06:00". Each clock time can
+// be in any form accepted by Parse, e.g. "9am-5:30pm".
+//
+// The error, if any, is a *ParseError.
+func ParseRange(value string) (Range, error) {
+	s := strings.TrimSpace(value)
+	hyphen := strings.IndexByte(s, '-')
+	if hyphen <= 0 {
+		return Range{}, &ParseError{Type: "clock.Range", Value: value, Reason: "expected start-end"}
+	}
+
+	start, err := Parse(strings.TrimSpace(s[:hyphen]))
+	if err != nil {
+		return Range{}, &ParseError{Type: "clock.Range", Value: value, Reason: "invalid start", Err: err}
+	}
+
+	end, err := Parse(strings.TrimSpace(s[hyphen+1:]))
+	if err != nil {
+		return Range{}, &ParseError{Type: "clock.Range", Value: value, Reason: "invalid end", Err: err}
+	}
+
+	return NewRange(start, end), nil
+}
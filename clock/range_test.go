@@ -0,0 +1,157 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRangeBasics(t *testing.T) {
+	cases := []struct {
+		r        Range
+		empty    bool
+		wraps    bool
+		duration time.Duration
+		str      string
+	}{
+		{NewRange(New(9, 0, 0, 0), New(17, 30, 0, 0)), false, false, 8*time.Hour + 30*time.Minute, "09:00-17:30"},
+		{NewRange(New(22, 0, 0, 0), New(6, 0, 0, 0)), false, true, 8 * time.Hour, "22:00-06:00"},
+		{NewRange(New(22, 0, 0, 0), Day), false, false, 2 * time.Hour, "22:00-24:00"},
+		{NewRange(New(22, 0, 0, 0), Midnight), false, true, 2 * time.Hour, "22:00-00:00"},
+		{NewRange(Midnight, Day), false, false, 24 * time.Hour, "00:00-24:00"},
+		{NewRange(New(9, 0, 0, 0), New(9, 0, 0, 0)), true, false, 0, "09:00-09:00"},
+		{NewRange(New(26, 0, 0, 0), New(-1, 0, 0, 0)), false, false, 21 * time.Hour, "02:00-23:00"},
+		{NewRange(New(9, 0, 15, 0), New(10, 0, 0, 0)), false, false, 59*time.Minute + 45*time.Second, "09:00:15-10:00:00"},
+		{NewRange(New(9, 0, 0, 5), New(10, 0, 0, 0)), false, false, time.Hour - 5*time.Millisecond, "09:00:00.005-10:00:00.000"},
+	}
+	for i, x := range cases {
+		if x.r.IsEmpty() != x.empty {
+			t.Errorf("%d: %v: IsEmpty got %v", i, x.r, x.r.IsEmpty())
+		}
+		if x.r.Wraps() != x.wraps {
+			t.Errorf("%d: %v: Wraps got %v", i, x.r, x.r.Wraps())
+		}
+		if x.r.Duration() != x.duration {
+			t.Errorf("%d: %v: Duration got %v, want %v", i, x.r, x.r.Duration(), x.duration)
+		}
+		if x.r.String() != x.str {
+			t.Errorf("%d: got %q, want %q", i, x.r.String(), x.str)
+		}
+		if r := MustParseRange(x.str); r != x.r {
+			t.Errorf("%d: %q: parsed %v, want %v", i, x.str, r, x.r)
+		}
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	day := MustParseRange("09:00-17:30")
+	night := MustParseRange("22:00-06:00")
+	cases := []struct {
+		c            Clock
+		inDay, night bool
+	}{
+		{New(0, 0, 0, 0), false, true},
+		{New(5, 59, 59, 999), false, true},
+		{New(6, 0, 0, 0), false, false},
+		{New(9, 0, 0, 0), true, false},
+		{New(17, 29, 59, 999), true, false},
+		{New(17, 30, 0, 0), false, false},
+		{New(22, 0, 0, 0), false, true},
+		{New(23, 0, 0, 0), false, true},
+		{Day, false, true},
+		{New(33, 0, 0, 0), true, false},
+		{New(-1, 0, 0, 0), false, true},
+	}
+	for i, x := range cases {
+		if day.Contains(x.c) != x.inDay {
+			t.Errorf("%d: %v contains %v: got %v", i, day, x.c, !x.inDay)
+		}
+		if night.Contains(x.c) != x.night {
+			t.Errorf("%d: %v contains %v: got %v", i, night, x.c, !x.night)
+		}
+	}
+}
+
+func TestRangeSplit(t *testing.T) {
+	cases := []struct {
+		r    string
+		want []Range
+	}{
+		{"09:00-17:30", []Range{MustParseRange("09:00-17:30")}},
+		{"22:00-06:00", []Range{MustParseRange("00:00-06:00"), MustParseRange("22:00-24:00")}},
+		{"22:00-00:00", []Range{MustParseRange("22:00-24:00")}},
+		{"09:00-09:00", nil},
+	}
+	for i, x := range cases {
+		got := MustParseRange(x.r).Split()
+		if !reflect.DeepEqual(got, x.want) {
+			t.Errorf("%d: %s: got %v, want %v", i, x.r, got, x.want)
+		}
+	}
+}
+
+func TestRangeIntersect(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want []string
+	}{
+		{"09:00-17:00", "12:00-20:00", []string{"12:00-17:00"}},
+		{"09:00-17:00", "17:00-20:00", nil},
+		{"09:00-17:00", "10:00-11:00", []string{"10:00-11:00"}},
+		{"22:00-06:00", "05:00-07:00", []string{"05:00-06:00"}},
+		{"22:00-06:00", "20:00-23:00", []string{"22:00-23:00"}},
+		{"22:00-06:00", "06:00-22:00", nil},
+		{"22:00-06:00", "23:00-02:00", []string{"23:00-02:00"}},
+		{"22:00-06:00", "05:00-23:00", []string{"05:00-06:00", "22:00-23:00"}},
+		{"22:00-06:00", "00:00-24:00", []string{"22:00-06:00"}},
+		{"22:00-00:00", "23:00-01:00", []string{"23:00-24:00"}},
+		{"09:00-09:00", "00:00-24:00", nil},
+	}
+	for i, x := range cases {
+		a, b := MustParseRange(x.a), MustParseRange(x.b)
+		for _, got := range [][]Range{a.Intersect(b), b.Intersect(a)} {
+			var strs []string
+			for _, r := range got {
+				strs = append(strs, r.String())
+			}
+			if !reflect.DeepEqual(strs, x.want) {
+				t.Errorf("%d: %s ∩ %s: got %v, want %v", i, x.a, x.b, strs, x.want)
+			}
+		}
+		if a.Overlaps(b) != (len(x.want) > 0) || b.Overlaps(a) != (len(x.want) > 0) {
+			t.Errorf("%d: %s overlaps %s: got %v", i, x.a, x.b, a.Overlaps(b))
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		str  string
+		want Range
+	}{
+		{"9am-5:30pm", NewRange(New(9, 0, 0, 0), New(17, 30, 0, 0))},
+		{" 22:00 - 06:00 ", NewRange(New(22, 0, 0, 0), New(6, 0, 0, 0))},
+		{"T2200-0600", NewRange(New(22, 0, 0, 0), New(6, 0, 0, 0))},
+	}
+	for i, x := range cases {
+		r, err := ParseRange(x.str)
+		if err != nil {
+			t.Errorf("%d: %s: %v", i, x.str, err)
+		} else if r != x.want {
+			t.Errorf("%d: %s: got %v, want %v", i, x.str, r, x.want)
+		}
+	}
+
+	bads := []string{"", "09:00", "-09:00", "09:00-", "xx-09:00", "09:00-yy"}
+	for i, s := range bads {
+		if r, err := ParseRange(s); err == nil {
+			t.Errorf("%d: %s: got %v", i, s, r)
+		} else if _, ok := err.(*ParseError); !ok {
+			t.Errorf("%d: %s: got %#v", i, s, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package clock
 import (
 	"database/sql/driver"
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -22,9 +23,9 @@ func (c *Clock) scanAny(value interface{}) (err error) {
 	case int64:
 		*c = Clock(value.(int64))
 	case []byte:
-		*c, err = Parse(string(value.([]byte)))
+		*c, err = scanString(string(value.([]byte)))
 	case string:
-		*c, err = Parse(value.(string))
+		*c, err = scanString(value.(string))
 	case time.Time:
 		*c = NewAt(value.(time.Time))
 	default:
@@ -33,9 +34,179 @@ func (c *Clock) scanAny(value interface{}) (err error) {
 	return
 }
 
+// scanString accepts all the formats of Parse and, failing that, those of ParseSQL.
+func scanString(value string) (Clock, error) {
+	c, err := Parse(value)
+	if err != nil {
+		if c2, e2 := ParseSQL(value); e2 == nil {
+			return c2, nil
+		}
+	}
+	return c, err
+}
+
 // Value converts the value to an int64. It implements driver.Valuer,
 // https://golang.org/pkg/database/sql/driver/#Valuer
 func (c Clock) Value() (driver.Value, error) {
 
 	return int64(c), nil
 }
+
+//-------------------------------------------------------------------------------------------------
+
+// ClockInt alters Clock to make database storage use an integer column holding the time
+// in the form HHMMSS, e.g. 93015 for 09:30:15. Milliseconds are not stored. (Otherwise,
+// Clock is stored as an integer number of milliseconds since midnight.)
+type ClockInt Clock
+
+// Clock provides a simple fluent type conversion to the underlying type.
+func (ci ClockInt) Clock() Clock {
+	return Clock(ci)
+}
+
+// ClockInt provides a simple fluent type conversion from the underlying type.
+func (c Clock) ClockInt() ClockInt {
+	return ClockInt(c)
+}
+
+// Scan parses some value. It implements sql.Scanner,
+// https://golang.org/pkg/database/sql/#Scanner
+// Integers are treated as HHMMSS values, as are strings and []byte containing only digits
+// (some drivers return integer columns this way); other values are handled as per Clock.Scan.
+func (ci *ClockInt) Scan(value interface{}) (err error) {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case int64:
+		return ci.scanHHMMSS(int(v))
+	case []byte:
+		return ci.scanString(string(v))
+	case string:
+		return ci.scanString(v)
+	}
+
+	return (*Clock)(ci).scanAny(value)
+}
+
+func (ci *ClockInt) scanString(value string) error {
+	if value != "" && allDigits(value) {
+		if n, err := strconv.Atoi(value); err == nil {
+			return ci.scanHHMMSS(n)
+		}
+	}
+	return (*Clock)(ci).scanAny(value)
+}
+
+func (ci *ClockInt) scanHHMMSS(hhmmss int) error {
+	c, err := FromHHMMSS(hhmmss)
+	if err == nil {
+		*ci = ClockInt(c)
+	}
+	return err
+}
+
+// Value converts the value to an int64 of the form HHMMSS. It implements driver.Valuer,
+// https://golang.org/pkg/database/sql/driver/#Valuer
+func (ci ClockInt) Value() (driver.Value, error) {
+	return int64(ci.Clock().HHMMSS()), nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Scan parses some value. It implements sql.Scanner,
+// https://golang.org/pkg/database/sql/#Scanner
+// The value can be a string or []byte (as per ParseOffsetClock), or a time.Time.
+func (oc *OffsetClock) Scan(value interface{}) (err error) {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		*oc, err = ParseOffsetClock(string(v))
+	case string:
+		*oc, err = ParseOffsetClock(v)
+	case time.Time:
+		*oc = NewOffsetClockAt(v)
+	default:
+		err = fmt.Errorf("%T %+v is not a meaningful clock with offset", value, value)
+	}
+	return err
+}
+
+// Value converts the value to a string suitable for an SQL TIME WITH TIME ZONE column.
+// It implements driver.Valuer, https://golang.org/pkg/database/sql/driver/#Valuer
+func (oc OffsetClock) Value() (driver.Value, error) {
+	return oc.String(), nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Scan parses some value. It implements sql.Scanner,
+// https://golang.org/pkg/database/sql/#Scanner
+// Integers are treated as nanoseconds since midnight. Strings are parsed as per ParseNano
+// and, failing that, ParseNanoSQL.
+func (n *Nano) Scan(value interface{}) (err error) {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case int64:
+		*n = Nano(v)
+	case []byte:
+		*n, err = scanNanoString(string(v))
+	case string:
+		*n, err = scanNanoString(v)
+	case time.Time:
+		*n = NewNanoAt(v)
+	default:
+		err = fmt.Errorf("%T %+v is not a meaningful clock", value, value)
+	}
+	return err
+}
+
+func scanNanoString(value string) (Nano, error) {
+	n, err := ParseNano(value)
+	if err != nil {
+		if n2, e2 := ParseNanoSQL(value); e2 == nil {
+			return n2, nil
+		}
+	}
+	return n, err
+}
+
+// Value converts the value to an int64 number of nanoseconds since midnight.
+// It implements driver.Valuer, https://golang.org/pkg/database/sql/driver/#Valuer
+func (n Nano) Value() (driver.Value, error) {
+	return int64(n), nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Scan parses some value. It implements sql.Scanner,
+// https://golang.org/pkg/database/sql/#Scanner
+// The value can be a string or []byte, as per ParseRange.
+func (r *Range) Scan(value interface{}) (err error) {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		*r, err = ParseRange(string(v))
+	case string:
+		*r, err = ParseRange(v)
+	default:
+		err = fmt.Errorf("%T %+v is not a meaningful clock range", value, value)
+	}
+	return err
+}
+
+// Value converts the value to a string such as "22:00-06:00".
+// It implements driver.Valuer, https://golang.org/pkg/database/sql/driver/#Valuer
+func (r Range) Value() (driver.Value, error) {
+	return r.String(), nil
+}
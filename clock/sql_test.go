@@ -20,6 +20,10 @@ func TestClockScan(t *testing.T) {
 		{"01:40:50.000pm", New(13, 40, 50, 0)},
 		{"4:20:00.000pm", New(16, 20, 0, 0)},
 		{[]byte("23:60:60.000"), New(0, 1, 0, 0)},
+		{"10:15:30.123456", New(10, 15, 30, 123)},
+		{[]byte("24:00:00"), New(24, 0, 0, 0)},
+		{"10:00:00+02", New(10, 0, 0, 0)},
+		{[]byte("10:00:00.5-05:30"), New(10, 0, 0, 500)},
 		{now, NewAt(now)},
 	}
 
@@ -71,3 +75,179 @@ func TestClockScanWithNil(t *testing.T) {
 		t.Errorf("Got %v", r)
 	}
 }
+
+func TestClockIntScan(t *testing.T) {
+	cases := []struct {
+		v        interface{}
+		expected Clock
+		value    int64
+	}{
+		{int64(93015), New(9, 30, 15, 0), 93015},
+		{int64(0), New(0, 0, 0, 0), 0},
+		{int64(240000), Day, 240000},
+		{"09:30:15", New(9, 30, 15, 0), 93015},
+		{[]byte("10:15:30.123456"), New(10, 15, 30, 123), 101530},
+		{"93015", New(9, 30, 15, 0), 93015},
+		{[]byte("93015"), New(9, 30, 15, 0), 93015},
+		{[]byte("0"), New(0, 0, 0, 0), 0},
+	}
+
+	for i, c := range cases {
+		var ci ClockInt
+		e := ci.Scan(c.v)
+		if e != nil {
+			t.Errorf("%d: Got %v for %v", i, e, c.expected)
+		} else if ci.Clock() != c.expected {
+			t.Errorf("%d: Got %v, want %v", i, ci.Clock(), c.expected)
+		}
+
+		var d driver.Valuer = ci
+
+		q, e := d.Value()
+		if e != nil {
+			t.Errorf("%d: Got %v for %v", i, e, c.expected)
+		} else if q.(int64) != c.value {
+			t.Errorf("%d: Got %v, want %d", i, q, c.value)
+		}
+	}
+
+	for _, v := range []interface{}{int64(93075), "93075", []byte("93075"), []byte("")} {
+		var ci ClockInt
+		if e := ci.Scan(v); e == nil {
+			t.Errorf("%v: Got %v, want error", v, ci.Clock())
+		}
+	}
+}
+
+func TestOffsetClockScan(t *testing.T) {
+	cases := []struct {
+		v        interface{}
+		expected OffsetClock
+		value    string
+	}{
+		{"10:00:00+02", NewOffsetClock(New(10, 0, 0, 0), 7200), "10:00:00.000+02:00"},
+		{[]byte("10:15:30.123456-05:30"), NewOffsetClock(New(10, 15, 30, 123), -19800), "10:15:30.123-05:30"},
+		{"24:00:00+00", NewOffsetClock(Day, 0), "24:00:00.000+00:00"},
+		{"08:30Z", NewOffsetClock(New(8, 30, 0, 0), 0), "08:30:00.000+00:00"},
+		{time.Date(2020, 1, 2, 3, 4, 5, 6000000, time.FixedZone("", 3600)), NewOffsetClock(New(3, 4, 5, 6), 3600), "03:04:05.006+01:00"},
+	}
+
+	for i, c := range cases {
+		var oc OffsetClock
+		e := oc.Scan(c.v)
+		if e != nil {
+			t.Errorf("%d: Got %v for %v", i, e, c.expected)
+		} else if oc != c.expected {
+			t.Errorf("%d: Got %v, want %v", i, oc, c.expected)
+		}
+
+		var d driver.Valuer = oc
+
+		q, e := d.Value()
+		if e != nil {
+			t.Errorf("%d: Got %v for %v", i, e, c.expected)
+		} else if q.(string) != c.value {
+			t.Errorf("%d: Got %v, want %v", i, q, c.value)
+		}
+	}
+}
+
+func TestOffsetClockScanWithJunk(t *testing.T) {
+	cases := []struct {
+		v        interface{}
+		expected string
+	}{
+		{true, "bool true is not a meaningful clock with offset"},
+		{int64(1), "int64 1 is not a meaningful clock with offset"},
+	}
+
+	for i, c := range cases {
+		var oc OffsetClock
+		e := oc.Scan(c.v)
+		if e.Error() != c.expected {
+			t.Errorf("%d: Got %q, want %q", i, e.Error(), c.expected)
+		}
+	}
+}
+
+func TestNanoScan(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		v        interface{}
+		expected Nano
+	}{
+		{int64(NewNano(10, 60, 10, 5)), NewNano(10, 60, 10, 5)},
+		{"12:00:00.400", NewNano(12, 0, 0, 400000000)},
+		{"01:40:50.000001pm", NewNano(13, 40, 50, 1000)},
+		{[]byte("10:15:30.123456789"), NewNano(10, 15, 30, 123456789)},
+		{"10:15:30.123456", NewNano(10, 15, 30, 123456000)},
+		{"10:00:00.000005+02", NewNano(10, 0, 0, 5000)},
+		{now, NewNanoAt(now)},
+	}
+
+	for i, c := range cases {
+		var n Nano
+		e := n.Scan(c.v)
+		if e != nil {
+			t.Errorf("%d: Got %v for %v", i, e, c.expected)
+		} else if n != c.expected {
+			t.Errorf("%d: Got %v, want %v", i, n, c.expected)
+		}
+
+		var d driver.Valuer = n
+
+		q, e := d.Value()
+		if e != nil {
+			t.Errorf("%d: Got %v for %v", i, e, c.expected)
+		} else if Nano(q.(int64)) != c.expected {
+			t.Errorf("%d: Got %v, want %v", i, q, c.expected)
+		}
+	}
+
+	var n Nano
+	if e := n.Scan(true); e == nil || e.Error() != "bool true is not a meaningful clock" {
+		t.Errorf("Got %v", e)
+	}
+	if e := n.Scan(nil); e != nil || n != 0 {
+		t.Errorf("Got %v %v", e, n)
+	}
+}
+
+func TestRangeScan(t *testing.T) {
+	cases := []struct {
+		v        interface{}
+		expected Range
+		value    string
+	}{
+		{"09:00-17:30", NewRange(New(9, 0, 0, 0), New(17, 30, 0, 0)), "09:00-17:30"},
+		{[]byte("22:00:00-06:00:00"), NewRange(New(22, 0, 0, 0), New(6, 0, 0, 0)), "22:00-06:00"},
+	}
+
+	for i, c := range cases {
+		var r Range
+		e := r.Scan(c.v)
+		if e != nil {
+			t.Errorf("%d: Got %v for %v", i, e, c.expected)
+		} else if r != c.expected {
+			t.Errorf("%d: Got %v, want %v", i, r, c.expected)
+		}
+
+		var d driver.Valuer = r
+
+		q, e := d.Value()
+		if e != nil {
+			t.Errorf("%d: Got %v for %v", i, e, c.expected)
+		} else if q.(string) != c.value {
+			t.Errorf("%d: Got %v, want %v", i, q, c.value)
+		}
+	}
+
+	var r Range
+	if e := r.Scan(int64(1)); e == nil || e.Error() != "int64 1 is not a meaningful clock range" {
+		t.Errorf("Got %v", e)
+	}
+	if e := r.Scan(nil); e != nil {
+		t.Errorf("Got %v", e)
+	}
+}
@@ -5,6 +5,7 @@
 package date
 
 import (
+	"fmt"
 	"math"
 	"time"
 
@@ -75,6 +76,37 @@ func NewOfDays(p PeriodOfDays) Date {
 	return Date{p}
 }
 
+// FromYYYYMMDD returns the Date value corresponding to an integer of the form YYYYMMDD,
+// such as 20261016, which is commonly used for keys in data warehouses. Negative values
+// represent dates before year 0 (see YYYYMMDD).
+//
+// Unlike New, the month and day are not normalised; an error is returned if either is
+// outside its usual range.
+func FromYYYYMMDD(v int) (Date, error) {
+	abs := v
+	if v < 0 {
+		abs = -v
+	}
+
+	year := abs / 10000
+	month := time.Month((abs / 100) % 100)
+	day := abs % 100
+
+	if month < time.January || month > time.December {
+		return Date{}, fmt.Errorf("Date.FromYYYYMMDD: cannot convert %d: invalid month", v)
+	}
+
+	if v < 0 {
+		year = -year
+	}
+
+	if day < 1 || day > DaysIn(year, month) {
+		return Date{}, fmt.Errorf("Date.FromYYYYMMDD: cannot convert %d: invalid day", v)
+	}
+
+	return New(year, month, day), nil
+}
+
 // Date returns the Date value corresponding to the given period since the
 // epoch (1st January 1970), which may be negative.
 func (p PeriodOfDays) Date() Date {
@@ -131,6 +163,47 @@ func (d Date) In(loc *time.Location) time.Time {
 	return t.Add(time.Duration(-offset) * time.Second)
 }
 
+// StartOfDayIn returns the first instant of the given date in the specified time zone.
+// This is usually midnight. However, in zones where the clocks go forward at midnight
+// (e.g. America/Sao_Paulo until 2019), there is no midnight on that day, so the result
+// is the time the clocks go forward to, typically 01:00. Unlike In, this is always on
+// the given date in the specified time zone.
+func (d Date) StartOfDayIn(loc *time.Location) time.Time {
+	y, m, day := d.Date()
+	t := time.Date(y, m, day, 0, 0, 0, 0, loc)
+	if hh, mm, ss := t.Clock(); hh == 0 && mm == 0 && ss == 0 && NewAt(t) == d {
+		return t
+	}
+
+	// midnight is in a gap, so search for the first second on the date; no zone offset
+	// exceeds a day, so this lies within a day either side of midnight UTC
+	lo := time.Date(y, m, day, 0, 0, 0, 0, time.UTC).Add(-24 * time.Hour).Unix()
+	hi := lo + 2*secondsPerDay
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if NewAt(time.Unix(mid, 0).In(loc)).Before(d) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return time.Unix(hi, 0).In(loc)
+}
+
+// EndOfDayIn returns the instant after the end of the given date in the specified time zone,
+// which is the start of the following date (see StartOfDayIn). Along with StartOfDayIn, this
+// gives a 'half-open' range in which the start is inclusive and the end is exclusive.
+func (d Date) EndOfDayIn(loc *time.Location) time.Time {
+	return d.Add(1).StartOfDayIn(loc)
+}
+
+// LengthIn returns the length of the day in the specified time zone, from its start to
+// its end (see StartOfDayIn and EndOfDayIn). This is 24 hours except on days when the
+// clocks change, when it is typically 23 or 25 hours. See also timespan.TransitionsIn.
+func (d Date) LengthIn(loc *time.Location) time.Duration {
+	return d.EndOfDayIn(loc).Sub(d.StartOfDayIn(loc))
+}
+
 // Date returns the year, month, and day of d.
 // The first day of the month is 1.
 func (d Date) Date() (year int, month time.Month, day int) {
@@ -138,6 +211,17 @@ func (d Date) Date() (year int, month time.Month, day int) {
 	return t.Date()
 }
 
+// YYYYMMDD returns the date as an integer of the form YYYYMMDD, such as 20261016.
+// For dates before year 0, the result is the negation of the corresponding value
+// for the absolute year, e.g. 31st December -1 gives -11231.
+func (d Date) YYYYMMDD() int {
+	year, month, day := d.Date()
+	if year < 0 {
+		return -(-year*10000 + int(month)*100 + day)
+	}
+	return year*10000 + int(month)*100 + day
+}
+
 // LastDayOfMonth returns the last day of the month specified by d.
 // The first day of the month is 1.
 func (d Date) LastDayOfMonth() int {
@@ -248,6 +332,9 @@ func (d Date) AddDate(years, months, days int) Date {
 // AddPeriod returns the date corresponding to adding the given period. If the
 // period's fields are be negative, this results in an earlier date.
 //
+// Mixed-sign periods are applied in the same order as period.Period.AddTo, so
+// P1M-1D (one month minus one day) from 15th January gives 14th February.
+//
 // Any time component is ignored. Therefore, be careful with periods containing
 // more that 24 hours in the hours/minutes/seconds fields. These will not be
 // normalised for you; if you want this behaviour, call delta.Normalise(false)
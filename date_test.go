@@ -52,6 +52,54 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestYYYYMMDD(t *testing.T) {
+	cases := []struct {
+		d Date
+		v int
+	}{
+		{New(2026, time.October, 16), 20261016},
+		{New(1970, time.January, 1), 19700101},
+		{New(2000, time.February, 29), 20000229},
+		{New(9999, time.December, 31), 99991231},
+		{New(12345, time.June, 7), 123450607},
+		{New(1, time.January, 1), 10101},
+		{New(0, time.January, 1), 101},
+		{New(-1, time.December, 31), -11231},
+	}
+	for _, c := range cases {
+		v := c.d.YYYYMMDD()
+		if v != c.v {
+			t.Errorf("YYYYMMDD(%v) == %d, want %d", c.d, v, c.v)
+		}
+		d, err := FromYYYYMMDD(c.v)
+		if err != nil {
+			t.Errorf("FromYYYYMMDD(%d) error %v", c.v, err)
+		} else if d != c.d {
+			t.Errorf("FromYYYYMMDD(%d) == %v, want %v", c.v, d, c.d)
+		}
+	}
+}
+
+func TestFromYYYYMMDDErrors(t *testing.T) {
+	cases := []struct {
+		v    int
+		want string
+	}{
+		{0, "Date.FromYYYYMMDD: cannot convert 0: invalid month"},
+		{20261300, "Date.FromYYYYMMDD: cannot convert 20261300: invalid month"},
+		{20261000, "Date.FromYYYYMMDD: cannot convert 20261000: invalid day"},
+		{20261032, "Date.FromYYYYMMDD: cannot convert 20261032: invalid day"},
+		{20190229, "Date.FromYYYYMMDD: cannot convert 20190229: invalid day"},
+		{17896, "Date.FromYYYYMMDD: cannot convert 17896: invalid month"},
+	}
+	for _, c := range cases {
+		_, err := FromYYYYMMDD(c.v)
+		if err == nil || err.Error() != c.want {
+			t.Errorf("FromYYYYMMDD(%d) error %v, want %s", c.v, err, c.want)
+		}
+	}
+}
+
 func TestDaysSinceEpoch(t *testing.T) {
 	zero := Date{}.DaysSinceEpoch()
 	if zero != 0 {
@@ -256,6 +304,9 @@ func TestAddPeriod(t *testing.T) {
 		{New(1974, time.January, 1), period.NewHMS(1, 2, 3), New(1974, time.January, 1)},
 		// note: the period is not normalised so the HMS is ignored even though it's more than one day
 		{New(1975, time.January, 1), period.NewHMS(24, 2, 3), New(1975, time.January, 1)},
+		// mixed-sign periods
+		{New(1976, time.January, 15), period.NewYMD(0, 1, -1), New(1976, time.February, 14)},
+		{New(1976, time.March, 15), period.NewYMD(0, -1, 1), New(1976, time.February, 16)},
 	}
 	for i, c := range cases {
 		out := c.in.AddPeriod(c.delta)
@@ -319,3 +370,68 @@ func TestDaysIn(t *testing.T) {
 		}
 	}
 }
+
+func TestLengthIn(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip(err)
+	}
+	cases := []struct {
+		d        Date
+		loc      *time.Location
+		expected time.Duration
+	}{
+		{New(2026, time.March, 28), london, 24 * time.Hour},
+		{New(2026, time.March, 29), london, 23 * time.Hour},
+		{New(2026, time.October, 25), london, 25 * time.Hour},
+		{New(2026, time.October, 25), time.UTC, 24 * time.Hour},
+	}
+	for _, c := range cases {
+		got := c.d.LengthIn(c.loc)
+		if got != c.expected {
+			t.Errorf("LengthIn(%v, %v) == %v, want %v", c.d, c.loc, got, c.expected)
+		}
+	}
+}
+
+func TestStartAndEndOfDayIn(t *testing.T) {
+	cases := []struct {
+		zone       string
+		d          Date
+		start, end time.Time // in UTC
+		length     time.Duration
+	}{
+		// ordinary days
+		{"Europe/London", New(2026, time.July, 1), time.Date(2026, time.June, 30, 23, 0, 0, 0, time.UTC), time.Date(2026, time.July, 1, 23, 0, 0, 0, time.UTC), 24 * time.Hour},
+		{"UTC", New(2026, time.July, 1), time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, time.July, 2, 0, 0, 0, 0, time.UTC), 24 * time.Hour},
+		// the clocks went forward from 00:00 to 01:00, so there was no midnight
+		{"America/Sao_Paulo", New(2018, time.November, 4), time.Date(2018, time.November, 4, 3, 0, 0, 0, time.UTC), time.Date(2018, time.November, 5, 2, 0, 0, 0, time.UTC), 23 * time.Hour},
+		{"Asia/Beirut", New(2019, time.March, 31), time.Date(2019, time.March, 30, 22, 0, 0, 0, time.UTC), time.Date(2019, time.March, 31, 21, 0, 0, 0, time.UTC), 23 * time.Hour},
+		{"America/Havana", New(2019, time.March, 10), time.Date(2019, time.March, 10, 5, 0, 0, 0, time.UTC), time.Date(2019, time.March, 11, 4, 0, 0, 0, time.UTC), 23 * time.Hour},
+		// the days before those
+		{"America/Sao_Paulo", New(2018, time.November, 3), time.Date(2018, time.November, 3, 3, 0, 0, 0, time.UTC), time.Date(2018, time.November, 4, 3, 0, 0, 0, time.UTC), 24 * time.Hour},
+		{"America/Havana", New(2019, time.March, 9), time.Date(2019, time.March, 9, 5, 0, 0, 0, time.UTC), time.Date(2019, time.March, 10, 5, 0, 0, 0, time.UTC), 24 * time.Hour},
+		// the clocks went back from 24:00 to 23:00 the previous day
+		{"Asia/Beirut", New(2019, time.October, 27), time.Date(2019, time.October, 26, 22, 0, 0, 0, time.UTC), time.Date(2019, time.October, 27, 22, 0, 0, 0, time.UTC), 24 * time.Hour},
+	}
+	for i, c := range cases {
+		loc, err := time.LoadLocation(c.zone)
+		if err != nil {
+			t.Skip(err)
+		}
+		start := c.d.StartOfDayIn(loc)
+		end := c.d.EndOfDayIn(loc)
+		if !start.Equal(c.start) || start.Location() != loc || NewAt(start) != c.d {
+			t.Errorf("%d: StartOfDayIn(%v, %s) == %v, want %v", i, c.d, c.zone, start, c.start)
+		}
+		if !end.Equal(c.end) || NewAt(end) != c.d.Add(1) {
+			t.Errorf("%d: EndOfDayIn(%v, %s) == %v, want %v", i, c.d, c.zone, end, c.end)
+		}
+		if NewAt(start.Add(-time.Second)) != c.d.Add(-1) {
+			t.Errorf("%d: StartOfDayIn(%v, %s) == %v is not the first instant", i, c.d, c.zone, start)
+		}
+		if l := c.d.LengthIn(loc); l != c.length {
+			t.Errorf("%d: LengthIn(%v, %s) == %v, want %v", i, c.d, c.zone, l, c.length)
+		}
+	}
+}
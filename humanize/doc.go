@@ -0,0 +1,12 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package humanize expresses periods, and the gaps between times or dates, as human-readable
+// relative phrases such as "just now", "yesterday", "3 days ago", "last week" and
+// "in about 2 months".
+//
+// The phrases are localisable via Names, which uses the same plurals as
+// period.Period.FormatWithPeriodNames. The rounding is controlled via Thresholds.
+//
+package humanize
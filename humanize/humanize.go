@@ -0,0 +1,224 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package humanize
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rickb777/plural"
+	"github.com/simplylizz/date"
+	"github.com/simplylizz/date/period"
+)
+
+// seconds per unit; months and years are as per Gregorian calendar rules
+const (
+	secondsPerMinute = 60
+	secondsPerHour   = 3600
+	secondsPerDay    = 86400
+	secondsPerWeek   = 7 * secondsPerDay
+	secondsPerMonth  = 2629746  // 30.436875 days
+	secondsPerYear   = 31556952 // 365.2425 days
+)
+
+// Names holds the localisable words and phrases used for relative times.
+// The phrases Past, Future and About must each contain one "%s" placeholder.
+type Names struct {
+	Now, Today, Yesterday, Tomorrow string
+	LastWeek, NextWeek              string
+	LastMonth, NextMonth            string
+	LastYear, NextYear              string
+
+	Past   string // e.g. "%s ago"
+	Future string // e.g. "in %s"
+	About  string // e.g. "about %s", used when a number of months or years is out by a day or more
+
+	Years, Months, Weeks, Days, Hours, Minutes, Seconds plural.Plurals
+}
+
+// English provides the English names. The plurals are the ones used by period.Period.Format.
+var English = Names{
+	Now:       "just now",
+	Today:     "today",
+	Yesterday: "yesterday",
+	Tomorrow:  "tomorrow",
+	LastWeek:  "last week",
+	NextWeek:  "next week",
+	LastMonth: "last month",
+	NextMonth: "next month",
+	LastYear:  "last year",
+	NextYear:  "next year",
+	Past:      "%s ago",
+	Future:    "in %s",
+	About:     "about %s",
+	Years:     period.PeriodYearNames,
+	Months:    period.PeriodMonthNames,
+	Weeks:     period.PeriodWeekNames,
+	Days:      period.PeriodDayNames,
+	Hours:     period.PeriodHourNames,
+	Minutes:   period.PeriodMinuteNames,
+	Seconds:   period.PeriodSecondNames,
+}
+
+// Thresholds control the rounding of relative times. Each amount is rounded to the
+// nearest whole number of the chosen unit, and the unit is the smallest one for which
+// the rounded count is below its threshold. Beyond the Months threshold, years are used.
+type Thresholds struct {
+	Now     time.Duration // anything shorter than this is "now"
+	Seconds int           // counts of seconds below this are expressed in seconds
+	Minutes int           // counts of minutes below this are expressed in minutes
+	Hours   int           // counts of hours below this are expressed in hours
+	Days    int           // counts of days below this are expressed in days
+	Weeks   int           // counts of weeks below this are expressed in weeks
+	Months  int           // counts of months below this are expressed in months
+}
+
+// DefaultThresholds are the thresholds used by default. With these, 40 seconds is
+// "just now", 100 seconds is "2 minutes", 50 minutes is "1 hour", 23 hours is "1 day",
+// 10 days is "1 week", 25 days is "1 month" and 11 months is "1 year".
+var DefaultThresholds = Thresholds{
+	Now:     45 * time.Second,
+	Seconds: 45,
+	Minutes: 45,
+	Hours:   22,
+	Days:    7,
+	Weeks:   4,
+	Months:  11,
+}
+
+// Relative expresses periods and the gaps between times or dates as human-readable
+// relative phrases, such as "3 days ago", "in 2 weeks" or "yesterday".
+type Relative struct {
+	Names      Names
+	Thresholds Thresholds
+}
+
+// Default uses English names and the default thresholds.
+var Default = Relative{Names: English, Thresholds: DefaultThresholds}
+
+// Period expresses a period relative to now, using the Default settings.
+// Positive periods are in the future and negative periods are in the past.
+func Period(p period.Period) string {
+	return Default.Period(p)
+}
+
+// Time expresses a time relative to a reference time (usually now), using the Default settings.
+func Time(t, ref time.Time) string {
+	return Default.Time(t, ref)
+}
+
+// Date expresses a date relative to a reference date (usually today), using the Default settings.
+func Date(d, ref date.Date) string {
+	return Default.Date(d, ref)
+}
+
+// Period expresses a period relative to now. Positive periods are in the future and
+// negative periods are in the past. The lengths of the years and months in the period
+// are estimated as per Gregorian calendar rules.
+func (r Relative) Period(p period.Period) string {
+	seconds := float64(p.YearsFloat())*secondsPerYear +
+		float64(p.MonthsFloat())*secondsPerMonth +
+		float64(p.DaysFloat())*secondsPerDay +
+		float64(p.HoursFloat())*secondsPerHour +
+		float64(p.MinutesFloat())*secondsPerMinute +
+		float64(p.SecondsFloat())
+	return r.phrase(seconds, false)
+}
+
+// Time expresses a time relative to a reference time (usually now). Times before the
+// reference are in the past and times after it are in the future.
+func (r Relative) Time(t, ref time.Time) string {
+	return r.phrase(t.Sub(ref).Seconds(), false)
+}
+
+// Date expresses a date relative to a reference date (usually today), so the result is
+// "today", "yesterday", "tomorrow" or a whole number of days or longer.
+func (r Relative) Date(d, ref date.Date) string {
+	if d == ref {
+		return r.Names.Today
+	}
+	return r.phrase(float64(d.Sub(ref))*secondsPerDay, true)
+}
+
+func (r Relative) phrase(seconds float64, days bool) string {
+	future := seconds > 0
+	abs := math.Abs(seconds)
+	th := r.Thresholds
+
+	if !days {
+		if abs < th.Now.Seconds() {
+			return r.Names.Now
+		}
+
+		if n := round(abs); n < th.Seconds {
+			return r.amount(future, period.NewHMS(0, 0, n), false)
+		}
+
+		if n := round(abs / secondsPerMinute); n < th.Minutes {
+			return r.amount(future, period.NewHMS(0, n, 0), false)
+		}
+
+		if n := round(abs / secondsPerHour); n < th.Hours {
+			return r.amount(future, period.NewHMS(n, 0, 0), false)
+		}
+	}
+
+	if n := round(abs / secondsPerDay); n < th.Days {
+		return r.oneOr(future, n, r.Names.Yesterday, r.Names.Tomorrow, period.NewYMD(0, 0, n), false)
+	}
+
+	if n := round(abs / secondsPerWeek); n < th.Weeks {
+		return r.oneOr(future, n, r.Names.LastWeek, r.Names.NextWeek, period.NewYMWD(0, 0, n, 0), false)
+	}
+
+	if n := round(abs / secondsPerMonth); n < th.Months {
+		return r.oneOr(future, n, r.Names.LastMonth, r.Names.NextMonth, period.NewYMD(0, n, 0),
+			inexact(abs, n, secondsPerMonth))
+	}
+
+	n := round(abs / secondsPerYear)
+	return r.oneOr(future, n, r.Names.LastYear, r.Names.NextYear, period.NewYMD(n, 0, 0),
+		inexact(abs, n, secondsPerYear))
+}
+
+func (r Relative) oneOr(future bool, n int, last, next string, p period.Period, about bool) string {
+	if n == 1 {
+		if future {
+			return next
+		}
+		return last
+	}
+	return r.amount(future, p, about)
+}
+
+func (r Relative) amount(future bool, p period.Period, about bool) string {
+	// weeks are only wanted when the period is in weeks
+	weeks := plural.Plurals{}
+	if p.ExplicitWeeks() != 0 {
+		weeks = r.Names.Weeks
+	}
+
+	s := p.FormatWithPeriodNames(r.Names.Years, r.Names.Months, weeks, r.Names.Days,
+		r.Names.Hours, r.Names.Minutes, r.Names.Seconds)
+
+	if about {
+		s = fmt.Sprintf(r.Names.About, s)
+	}
+
+	if future {
+		return fmt.Sprintf(r.Names.Future, s)
+	}
+	return fmt.Sprintf(r.Names.Past, s)
+}
+
+func round(v float64) int {
+	return int(math.Floor(v + 0.5))
+}
+
+// inexact is true when n whole units differ from the actual amount by more than a day
+func inexact(seconds float64, n int, unit float64) bool {
+	return math.Abs(seconds-float64(n)*unit) >= secondsPerDay
+}
@@ -0,0 +1,149 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package humanize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/plural"
+	"github.com/simplylizz/date"
+	"github.com/simplylizz/date/period"
+)
+
+func TestPeriod(t *testing.T) {
+	cases := []struct {
+		p        string
+		expected string
+	}{
+		{"P0D", "just now"},
+		{"PT30S", "just now"},
+		{"-PT50S", "1 minute ago"},
+		{"PT100S", "in 2 minutes"},
+		{"PT44M", "in 44 minutes"},
+		{"PT50M", "in 1 hour"},
+		{"-PT3H", "3 hours ago"},
+		{"PT23H", "tomorrow"},
+		{"-P1D", "yesterday"},
+		{"-P3D", "3 days ago"},
+		{"P6D", "in 6 days"},
+		{"P1W", "next week"},
+		{"-P10D", "last week"},
+		{"P2W", "in 2 weeks"},
+		{"-P3W", "3 weeks ago"},
+		{"P25D", "next month"},
+		{"P2M", "in 2 months"},
+		{"P58D", "in about 2 months"},
+		{"-P10M", "10 months ago"},
+		{"P11M", "next year"},
+		{"-P1Y", "last year"},
+		{"P3Y", "in 3 years"},
+		{"P3Y2M", "in about 3 years"},
+		{"P1M-1D", "next month"},
+	}
+
+	for i, c := range cases {
+		s := Period(period.MustParse(c.p, false))
+		if s != c.expected {
+			t.Errorf("%d: %s: got %q, want %q", i, c.p, s, c.expected)
+		}
+	}
+}
+
+func TestTime(t *testing.T) {
+	ref := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		t        time.Time
+		expected string
+	}{
+		{ref, "just now"},
+		{ref.Add(-10 * time.Second), "just now"},
+		{ref.Add(-5 * time.Minute), "5 minutes ago"},
+		{ref.Add(90 * time.Minute), "in 2 hours"},
+		{ref.AddDate(0, 0, -1), "yesterday"},
+		{ref.AddDate(0, 0, 3), "in 3 days"},
+		{ref.AddDate(0, 0, -14), "2 weeks ago"},
+		{ref.AddDate(0, 2, 0), "in 2 months"},
+		{ref.AddDate(0, 0, 57), "in about 2 months"},
+		{ref.AddDate(-5, 0, 0), "5 years ago"},
+	}
+
+	for i, c := range cases {
+		s := Time(c.t, ref)
+		if s != c.expected {
+			t.Errorf("%d: %v: got %q, want %q", i, c.t, s, c.expected)
+		}
+	}
+}
+
+func TestDate(t *testing.T) {
+	today := date.New(2026, time.October, 18)
+
+	cases := []struct {
+		d        date.Date
+		expected string
+	}{
+		{today, "today"},
+		{today.Add(-1), "yesterday"},
+		{today.Add(1), "tomorrow"},
+		{today.Add(-3), "3 days ago"},
+		{today.Add(7), "next week"},
+		{today.Add(-21), "3 weeks ago"},
+		{today.AddDate(0, -1, 0), "last month"},
+		{today.AddDate(0, 0, 58), "in about 2 months"},
+		{today.AddDate(0, 0, 60), "in 2 months"},
+		{today.AddDate(1, 0, 0), "next year"},
+	}
+
+	for i, c := range cases {
+		s := Date(c.d, today)
+		if s != c.expected {
+			t.Errorf("%d: %v: got %q, want %q", i, c.d, s, c.expected)
+		}
+	}
+}
+
+func TestCustomThresholdsAndNames(t *testing.T) {
+	french := Names{
+		Now: "à l'instant", Today: "aujourd'hui", Yesterday: "hier", Tomorrow: "demain",
+		LastWeek: "la semaine dernière", NextWeek: "la semaine prochaine",
+		LastMonth: "le mois dernier", NextMonth: "le mois prochain",
+		LastYear: "l'année dernière", NextYear: "l'année prochaine",
+		Past: "il y a %s", Future: "dans %s", About: "environ %s",
+		Years:   plural.FromZero("", "%v an", "%v ans"),
+		Months:  plural.FromZero("", "%v mois", "%v mois"),
+		Weeks:   plural.FromZero("", "%v semaine", "%v semaines"),
+		Days:    plural.FromZero("", "%v jour", "%v jours"),
+		Hours:   plural.FromZero("", "%v heure", "%v heures"),
+		Minutes: plural.FromZero("", "%v minute", "%v minutes"),
+		Seconds: plural.FromZero("", "%v seconde", "%v secondes"),
+	}
+
+	th := DefaultThresholds
+	th.Now = 5 * time.Second
+	th.Days = 14
+
+	r := Relative{Names: french, Thresholds: th}
+
+	cases := []struct {
+		p        string
+		expected string
+	}{
+		{"PT3S", "à l'instant"},
+		{"-PT10S", "il y a 10 secondes"},
+		{"P10D", "dans 10 jours"},
+		{"-P1D", "hier"},
+		{"P3W", "dans 3 semaines"},
+		{"P58D", "dans environ 2 mois"},
+	}
+
+	for i, c := range cases {
+		s := r.Period(period.MustParse(c.p, false))
+		if s != c.expected {
+			t.Errorf("%d: %s: got %q, want %q", i, c.p, s, c.expected)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/simplylizz/date/clock"
+	"github.com/simplylizz/date/period"
+)
+
+// LocalDateTime is a date and a time of day without any time zone, such as
+// "2026-10-16T09:30:00". This corresponds to the SQL TIMESTAMP WITHOUT TIME ZONE type.
+// It describes a wall-clock time rather than an instant; use In to find the instant in
+// a particular location. The time of day has millisecond precision.
+//
+// LocalDateTime values can be compared using == and !=, as well as Before, After
+// and Compare.
+type LocalDateTime struct {
+	date  Date
+	clock clock.Clock // always in the range 00:00 to 23:59:59.999
+}
+
+// NewLocalDateTime combines a date and a clock time. The clock time can be outside the
+// range of a single day, e.g. 26:00 or -01:00, in which case the date is adjusted
+// accordingly. So 24:00 is midnight at the start of the following day.
+func NewLocalDateTime(d Date, c clock.Clock) LocalDateTime {
+	days, rem := c/clock.Day, c%clock.Day
+	if rem < 0 {
+		days--
+		rem += clock.Day
+	}
+	return LocalDateTime{d.Add(PeriodOfDays(days)), rem}
+}
+
+// LocalDateTimeOf returns the wall-clock date and time of a time.Time in its own location.
+// Any sub-millisecond part is truncated.
+func LocalDateTimeOf(t time.Time) LocalDateTime {
+	return LocalDateTime{NewAt(t), clock.NewAt(t)}
+}
+
+// Date returns the date part.
+func (ldt LocalDateTime) Date() Date {
+	return ldt.date
+}
+
+// Clock returns the time of day part.
+func (ldt LocalDateTime) Clock() clock.Clock {
+	return ldt.clock
+}
+
+// IsZero reports whether ldt is the zero value, i.e. midnight at the start of the epoch.
+func (ldt LocalDateTime) IsZero() bool {
+	return ldt.date.IsZero() && ldt.clock == clock.Midnight
+}
+
+// Before reports whether ldt is before u.
+func (ldt LocalDateTime) Before(u LocalDateTime) bool {
+	return ldt.Compare(u) < 0
+}
+
+// After reports whether ldt is after u.
+func (ldt LocalDateTime) After(u LocalDateTime) bool {
+	return ldt.Compare(u) > 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether ldt is before, equal to or after u.
+func (ldt LocalDateTime) Compare(u LocalDateTime) int {
+	switch {
+	case ldt.date.Before(u.date):
+		return -1
+	case ldt.date.After(u.date):
+		return 1
+	case ldt.clock < u.clock:
+		return -1
+	case ldt.clock > u.clock:
+		return 1
+	}
+	return 0
+}
+
+// Add returns the date-time offset by a duration, which can be negative. The arithmetic
+// is on the wall clock, so every day has 24 hours. Any sub-millisecond part of the result
+// is truncated.
+func (ldt LocalDateTime) Add(d time.Duration) LocalDateTime {
+	return LocalDateTimeOf(ldt.wall(time.UTC).Add(d))
+}
+
+// AddPeriod returns the date-time offset by a period, which can be negative. The years,
+// months and days are added to the date as per Date.AddPeriod, then the hours, minutes
+// and seconds are added to the wall clock. If the period contains fractions of years,
+// months or days, the result is only an approximation, as per period.Period.AddTo.
+func (ldt LocalDateTime) AddPeriod(delta period.Period) LocalDateTime {
+	t, _ := delta.AddTo(ldt.wall(time.UTC))
+	return LocalDateTimeOf(t)
+}
+
+// Sub returns the wall-clock duration ldt-u, in which every day has 24 hours. If the
+// result exceeds the range of time.Duration, it is clamped to the minimum or maximum.
+func (ldt LocalDateTime) Sub(u LocalDateTime) time.Duration {
+	return ldt.wall(time.UTC).Sub(u.wall(time.UTC))
+}
+
+// wall gets the time.Time with the same wall-clock date and time in a location; the
+// choice of instant in a daylight-saving gap or overlap is as per time.Date.
+func (ldt LocalDateTime) wall(loc *time.Location) time.Time {
+	y, m, d := ldt.date.Date()
+	c := ldt.clock
+	return time.Date(y, m, d, c.Hours(), c.Minutes(), c.Seconds(), c.Millisec()*int(time.Millisecond), loc)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// GapPolicy determines how In treats a wall-clock time that does not exist in a location,
+// because it falls in the gap when the clocks go forward.
+type GapPolicy int
+
+const (
+	// GapShiftForward moves the time forward by the length of the gap, so in a one-hour
+	// gap from 01:00 to 02:00, 01:30 becomes 02:30.
+	GapShiftForward GapPolicy = iota
+	// GapReject returns an error.
+	GapReject
+)
+
+// OverlapPolicy determines how In treats a wall-clock time that occurs twice in a location,
+// because it falls in the overlap when the clocks go back.
+type OverlapPolicy int
+
+const (
+	// OverlapEarlier chooses the earlier instant, i.e. before the clocks go back.
+	OverlapEarlier OverlapPolicy = iota
+	// OverlapLater chooses the later instant, i.e. after the clocks go back.
+	OverlapLater
+	// OverlapReject returns an error.
+	OverlapReject
+)
+
+// UTC returns the instant for the date-time in UTC. This never fails because UTC has
+// no daylight saving.
+func (ldt LocalDateTime) UTC() time.Time {
+	return ldt.wall(time.UTC)
+}
+
+// In returns the instant for the date-time in a location. When the clocks change, a
+// wall-clock time may not exist (in a gap) or may occur twice (in an overlap); the
+// policies determine what happens in these cases. An error is returned only if a
+// policy rejects the time.
+func (ldt LocalDateTime) In(loc *time.Location, gap GapPolicy, overlap OverlapPolicy) (time.Time, error) {
+	w := ldt.wall(time.UTC)
+
+	// the offsets either side of any clock change, assuming there's no more than one per day
+	_, before := w.Add(-24 * time.Hour).In(loc).Zone()
+	_, after := w.Add(24 * time.Hour).In(loc).Zone()
+
+	var valid []time.Time
+	for _, offset := range []int{before, after} {
+		t := w.Add(time.Duration(-offset) * time.Second).In(loc)
+		if _, o := t.Zone(); o == offset && (len(valid) == 0 || !valid[0].Equal(t)) {
+			valid = append(valid, t)
+		}
+	}
+
+	switch len(valid) {
+	case 1:
+		return valid[0], nil
+
+	case 2:
+		switch overlap {
+		case OverlapEarlier:
+			return earlier(valid[0], valid[1]), nil
+		case OverlapLater:
+			return later(valid[0], valid[1]), nil
+		}
+		return time.Time{}, fmt.Errorf("LocalDateTime.In: %s is ambiguous in %s", ldt, loc)
+	}
+
+	if gap == GapShiftForward {
+		return w.Add(time.Duration(-before) * time.Second).In(loc), nil
+	}
+	return time.Time{}, fmt.Errorf("LocalDateTime.In: %s does not exist in %s", ldt, loc)
+}
+
+func earlier(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// String returns the date-time in ISO 8601 extended format, e.g. "2026-10-16T09:30:00".
+// The milliseconds are included only if they are not zero, e.g. "2026-10-16T09:30:00.250".
+func (ldt LocalDateTime) String() string {
+	if ldt.clock%clock.Second != 0 {
+		return ldt.date.String() + "T" + ldt.clock.String()
+	}
+	return ldt.date.String() + "T" + ldt.clock.HhMmSs()
+}
+
+// MustParseLocalDateTime is as per ParseLocalDateTime except that it panics if the string
+// cannot be parsed. This is intended for setup code; don't use it for user inputs.
+func MustParseLocalDateTime(value string) LocalDateTime {
+	ldt, err := ParseLocalDateTime(value)
+	if err != nil {
+		panic(err)
+	}
+	return ldt
+}
+
+// ParseLocalDateTime parses an ISO 8601 date and time without any time zone, such as
+// "2026-10-16T09:30:00". The date is as per ParseISO and the time is as per clock.ParseISO,
+// except that a UTC offset or "Z" is not allowed. A space can be used instead of the "T"
+// (as is usual for SQL). A time of 24:00 is midnight at the start of the following day.
+func ParseLocalDateTime(value string) (LocalDateTime, error) {
+	sep := strings.IndexAny(value, "Tt ")
+	if sep < 0 {
+		return LocalDateTime{}, fmt.Errorf("LocalDateTime: cannot parse %q: missing time", value)
+	}
+
+	d, err := ParseISO(value[:sep])
+	if err != nil {
+		return LocalDateTime{}, fmt.Errorf("LocalDateTime: cannot parse %q: %v", value, err)
+	}
+
+	hms := value[sep+1:]
+	if strings.ContainsAny(hms, "Zz+-") {
+		return LocalDateTime{}, fmt.Errorf("LocalDateTime: cannot parse %q: unexpected time zone", value)
+	}
+
+	c, err := clock.ParseISO(hms)
+	if err != nil {
+		return LocalDateTime{}, fmt.Errorf("LocalDateTime: cannot parse %q: %v", value, err)
+	}
+
+	return NewLocalDateTime(d, c), nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"testing"
+	"time"
+
+	"github.com/simplylizz/date/clock"
+	"github.com/simplylizz/date/period"
+)
+
+func TestNewLocalDateTime(t *testing.T) {
+	cases := []struct {
+		d    Date
+		c    clock.Clock
+		want string
+	}{
+		{New(2026, time.October, 16), clock.New(9, 30, 0, 0), "2026-10-16T09:30:00"},
+		{New(2026, time.October, 16), clock.New(9, 30, 0, 250), "2026-10-16T09:30:00.250"},
+		{New(2026, time.October, 16), clock.Day, "2026-10-17T00:00:00"},
+		{New(2026, time.October, 16), clock.New(50, 0, 0, 0), "2026-10-18T02:00:00"},
+		{New(2026, time.October, 16), clock.New(-1, 0, 0, 0), "2026-10-15T23:00:00"},
+		{New(2026, time.January, 1), clock.New(0, 0, 0, -1), "2025-12-31T23:59:59.999"},
+		{New(2026, time.October, 16), -clock.Day, "2026-10-15T00:00:00"},
+		{New(2026, time.October, 16), -2 * clock.Day, "2026-10-14T00:00:00"},
+		{New(2026, time.October, 16), clock.New(-33, -30, 0, 0), "2026-10-14T14:30:00"},
+	}
+	for i, c := range cases {
+		ldt := NewLocalDateTime(c.d, c.c)
+		if ldt.String() != c.want {
+			t.Errorf("%d: got %s, want %s", i, ldt, c.want)
+		}
+		if !ldt.Clock().IsInOneDay() || ldt.Clock() == clock.Day {
+			t.Errorf("%d: got clock %v", i, ldt.Clock())
+		}
+	}
+
+	ldt := LocalDateTimeOf(time.Date(2026, time.October, 16, 9, 30, 15, 123456789, time.FixedZone("", 3600)))
+	if ldt != MustParseLocalDateTime("2026-10-16T09:30:15.123") {
+		t.Errorf("got %v", ldt)
+	}
+}
+
+func TestLocalDateTimeComparison(t *testing.T) {
+	a := MustParseLocalDateTime("2026-10-16T09:30:00")
+	b := MustParseLocalDateTime("2026-10-16T09:30:00.001")
+	c := MustParseLocalDateTime("2026-10-17T00:00:00")
+
+	if !a.Before(b) || !b.Before(c) || a.After(b) || !c.After(a) {
+		t.Errorf("wrong order")
+	}
+	if a.Compare(a) != 0 || a.Compare(c) != -1 || c.Compare(b) != 1 {
+		t.Errorf("wrong compare")
+	}
+	if !(LocalDateTime{}).IsZero() || a.IsZero() {
+		t.Errorf("wrong IsZero")
+	}
+}
+
+func TestLocalDateTimeArithmetic(t *testing.T) {
+	start := MustParseLocalDateTime("2026-01-31T22:00:00")
+	cases := []struct {
+		p    period.Period
+		want string
+	}{
+		{period.MustParse("PT3H"), "2026-02-01T01:00:00"},
+		{period.MustParse("P1M"), "2026-03-03T22:00:00"},
+		{period.MustParse("P1D"), "2026-02-01T22:00:00"},
+		{period.MustParse("P1Y2M3DT4H5M6S"), "2027-04-04T02:05:06"},
+		{period.MustParse("-PT23H"), "2026-01-30T23:00:00"},
+	}
+	for i, c := range cases {
+		if got := start.AddPeriod(c.p); got.String() != c.want {
+			t.Errorf("%d: %v + %v: got %s, want %s", i, start, c.p, got, c.want)
+		}
+	}
+
+	durations := []struct {
+		from string
+		d    time.Duration
+		want string
+	}{
+		{"2026-01-31T22:00:00", 26 * time.Hour, "2026-02-02T00:00:00"},
+		{"2026-10-16T00:00:00", -24 * time.Hour, "2026-10-15T00:00:00"},
+		{"2026-10-16T00:00:00", -48 * time.Hour, "2026-10-14T00:00:00"},
+		{"2026-10-16T09:30:00", -(33*time.Hour + 30*time.Minute), "2026-10-15T00:00:00"},
+		{"2026-10-16T09:30:00", -(9*time.Hour + 31*time.Minute), "2026-10-15T23:59:00"},
+		{"2026-10-16T00:00:00", 30 * 24 * time.Hour, "2026-11-15T00:00:00"},
+		{"2026-10-16T00:00:00", -30 * 24 * time.Hour, "2026-09-16T00:00:00"},
+		{"2026-10-16T12:00:00", 1000*24*time.Hour + 90*time.Minute, "2029-07-12T13:30:00"},
+		{"2026-10-16T12:00:00", -1000*24*time.Hour - 90*time.Minute, "2024-01-20T10:30:00"},
+		{"2026-10-16T12:00:00", 1500 * time.Microsecond, "2026-10-16T12:00:00.001"},
+	}
+	for i, c := range durations {
+		if got := MustParseLocalDateTime(c.from).Add(c.d); got.String() != c.want {
+			t.Errorf("%d: %s + %v: got %s, want %s", i, c.from, c.d, got, c.want)
+		}
+	}
+	if d := MustParseLocalDateTime("2026-03-30T00:00:00").Sub(start); d != 1370*time.Hour {
+		t.Errorf("got %v", d)
+	}
+}
+
+func TestParseLocalDateTime(t *testing.T) {
+	cases := []struct {
+		str, want string
+	}{
+		{"2026-10-16T09:30:00", "2026-10-16T09:30:00"},
+		{"2026-10-16 09:30:00.123456", "2026-10-16T09:30:00.123"},
+		{"20261016T0930", "2026-10-16T09:30:00"},
+		{"2026-10-16T24:00", "2026-10-17T00:00:00"},
+		{"+12345-06-07T10:00", "+12345-06-07T10:00:00"},
+	}
+	for i, c := range cases {
+		ldt, err := ParseLocalDateTime(c.str)
+		if err != nil {
+			t.Errorf("%d: %s: %v", i, c.str, err)
+		} else if ldt.String() != c.want {
+			t.Errorf("%d: %s: got %s, want %s", i, c.str, ldt, c.want)
+		}
+	}
+
+	bads := []string{"", "2026-10-16", "2026-10-16T", "2026-10-16T9:30", "2026-10-16T09:60", "2026-10-16T09:30Z", "2026-10-16T09:30+01:00"}
+	for i, s := range bads {
+		if ldt, err := ParseLocalDateTime(s); err == nil {
+			t.Errorf("%d: %s: got %v", i, s, ldt)
+		}
+	}
+}
+
+func TestLocalDateTimeIn(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	utc := func(s string) time.Time {
+		return MustParseLocalDateTime(s).UTC()
+	}
+
+	cases := []struct {
+		ldt     string
+		gap     GapPolicy
+		overlap OverlapPolicy
+		want    time.Time
+		ok      bool
+	}{
+		// ordinary times
+		{"2026-07-01T12:00:00", GapReject, OverlapReject, utc("2026-07-01T11:00:00"), true},
+		{"2026-12-01T12:00:00", GapReject, OverlapReject, utc("2026-12-01T12:00:00"), true},
+		// the clocks go forward at 01:00 UTC on 29th March 2026
+		{"2026-03-29T00:59:59", GapReject, OverlapReject, utc("2026-03-29T00:59:59"), true},
+		{"2026-03-29T01:30:00", GapShiftForward, OverlapReject, utc("2026-03-29T01:30:00"), true},
+		{"2026-03-29T01:30:00", GapReject, OverlapEarlier, time.Time{}, false},
+		{"2026-03-29T02:00:00", GapReject, OverlapReject, utc("2026-03-29T01:00:00"), true},
+		// the clocks go back at 01:00 UTC on 25th October 2026
+		{"2026-10-25T01:30:00", GapReject, OverlapEarlier, utc("2026-10-25T00:30:00"), true},
+		{"2026-10-25T01:30:00", GapReject, OverlapLater, utc("2026-10-25T01:30:00"), true},
+		{"2026-10-25T01:30:00", GapShiftForward, OverlapReject, time.Time{}, false},
+		{"2026-10-25T02:00:00", GapReject, OverlapReject, utc("2026-10-25T02:00:00"), true},
+	}
+	for i, c := range cases {
+		got, err := MustParseLocalDateTime(c.ldt).In(london, c.gap, c.overlap)
+		if c.ok {
+			if err != nil {
+				t.Errorf("%d: %s: %v", i, c.ldt, err)
+			} else if !got.Equal(c.want) {
+				t.Errorf("%d: %s: got %v, want %v", i, c.ldt, got, c.want)
+			} else if got.Location() != london {
+				t.Errorf("%d: %s: got %v", i, c.ldt, got.Location())
+			}
+		} else if err == nil {
+			t.Errorf("%d: %s: got %v, want error", i, c.ldt, got)
+		}
+	}
+}
@@ -6,6 +6,9 @@ package date
 
 import (
 	"errors"
+	"strconv"
+
+	"github.com/simplylizz/date/clock"
 )
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
@@ -84,3 +87,99 @@ func (ds DateString) MarshalText() ([]byte, error) {
 func (ds *DateString) UnmarshalText(data []byte) (err error) {
 	return (*Date)(ds).UnmarshalText(data)
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (di DateInt) MarshalBinary() ([]byte, error) {
+	return Date(di).MarshalBinary()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (di *DateInt) UnmarshalBinary(data []byte) error {
+	return (*Date)(di).UnmarshalBinary(data)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The date is given as the digits of its YYYYMMDD integer form (e.g. "20261016").
+func (di DateInt) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(di.Date().YYYYMMDD())), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The date is expected to be the digits of its YYYYMMDD integer form (e.g. "20261016"),
+// although any ISO 8601 form accepted by ParseISO is also allowed.
+func (di *DateInt) UnmarshalText(data []byte) (err error) {
+	n, err := strconv.Atoi(string(data))
+	if err != nil {
+		var d Date
+		d, err = ParseISO(string(data))
+		if err == nil {
+			*di = DateInt(d)
+		}
+		return err
+	}
+
+	d, err := FromYYYYMMDD(n)
+	if err == nil {
+		*di = DateInt(d)
+	}
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The date is given as a YYYYMMDD number (e.g. 20261016).
+func (di DateInt) MarshalJSON() ([]byte, error) {
+	return di.MarshalText()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The date is expected to be a YYYYMMDD number (e.g. 20261016), although
+// a string is also allowed as per UnmarshalText.
+func (di *DateInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	return di.UnmarshalText(data)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (ldt LocalDateTime) MarshalBinary() ([]byte, error) {
+	enc, _ := ldt.date.MarshalBinary()
+	c, _ := ldt.clock.MarshalBinary()
+	return append(enc, c...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (ldt *LocalDateTime) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("LocalDateTime.UnmarshalBinary: no data")
+	}
+	if len(data) != 8 {
+		return errors.New("LocalDateTime.UnmarshalBinary: invalid length")
+	}
+
+	var d Date
+	var c clock.Clock
+	d.UnmarshalBinary(data[:4])
+	c.UnmarshalBinary(data[4:])
+	*ldt = NewLocalDateTime(d, c)
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The date-time is given in ISO 8601 extended format (e.g. "2026-10-16T09:30:00").
+func (ldt LocalDateTime) MarshalText() ([]byte, error) {
+	return []byte(ldt.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The date-time is parsed as per ParseLocalDateTime.
+func (ldt *LocalDateTime) UnmarshalText(data []byte) (err error) {
+	u, err := ParseLocalDateTime(string(data))
+	if err == nil {
+		*ldt = u
+	}
+	return err
+}
@@ -147,6 +147,75 @@ func TestDateTextMarshalling(t *testing.T) {
 	}
 }
 
+func TestDateIntMarshalling(t *testing.T) {
+	cases := []struct {
+		value Date
+		want  string
+	}{
+		{New(0, time.January, 1), "101"},
+		{New(1970, time.January, 1), "19700101"},
+		{New(2026, time.October, 16), "20261016"},
+		{New(12345, time.June, 7), "123450607"},
+		{New(-1, time.December, 31), "-11231"},
+	}
+	for _, c := range cases {
+		var di DateInt
+		bb1, err := json.Marshal(c.value.DateInt())
+		if err != nil {
+			t.Errorf("JSON(%v) marshal error %v", c, err)
+		} else if string(bb1) != c.want {
+			t.Errorf("JSON(%v) == %v, want %v", c.value, string(bb1), c.want)
+		} else {
+			err = json.Unmarshal(bb1, &di)
+			if err != nil {
+				t.Errorf("JSON(%v) unmarshal error %v", c.value, err)
+			} else if di != c.value.DateInt() {
+				t.Errorf("JSON(%v) unmarshal got %v", c.value, di)
+			}
+		}
+
+		bb2, err := c.value.DateInt().MarshalText()
+		if err != nil {
+			t.Errorf("Text(%v) marshal error %v", c, err)
+		} else if string(bb2) != c.want {
+			t.Errorf("Text(%v) == %v, want %v", c.value, string(bb2), c.want)
+		} else {
+			err = di.UnmarshalText(bb2)
+			if err != nil {
+				t.Errorf("Text(%v) unmarshal error %v", c.value, err)
+			} else if di != c.value.DateInt() {
+				t.Errorf("Text(%v) unmarshal got %v", c.value, di)
+			}
+		}
+	}
+}
+
+func TestDateIntUnmarshalling(t *testing.T) {
+	cases := []struct {
+		json string
+		want Date
+	}{
+		{`20261016`, New(2026, time.October, 16)},
+		{`"20261016"`, New(2026, time.October, 16)},
+		{`"2026-10-16"`, New(2026, time.October, 16)},
+	}
+	for _, c := range cases {
+		var di DateInt
+		err := json.Unmarshal([]byte(c.json), &di)
+		if err != nil {
+			t.Errorf("JSON(%v) unmarshal error %v", c.json, err)
+		} else if di.Date() != c.want {
+			t.Errorf("JSON(%v) unmarshal got %v", c.json, di.Date())
+		}
+	}
+
+	var di DateInt
+	err := json.Unmarshal([]byte(`17896`), &di)
+	if err == nil {
+		t.Errorf("JSON(17896) unmarshal got %v, want error", di.Date())
+	}
+}
+
 func TestDateBinaryMarshalling(t *testing.T) {
 	cases := []struct {
 		value Date
@@ -217,3 +286,48 @@ func TestInvalidDateText(t *testing.T) {
 		}
 	}
 }
+
+func TestLocalDateTimeMarshalling(t *testing.T) {
+	cases := []struct {
+		value LocalDateTime
+		want  string
+	}{
+		{MustParseLocalDateTime("2026-10-16T09:30:00"), `"2026-10-16T09:30:00"`},
+		{MustParseLocalDateTime("1969-12-31T23:59:59.999"), `"1969-12-31T23:59:59.999"`},
+		{LocalDateTime{}, `"1970-01-01T00:00:00"`},
+	}
+	for _, c := range cases {
+		bb, err := json.Marshal(c.value)
+		if err != nil {
+			t.Errorf("JSON(%v) marshal error %v", c.value, err)
+		} else if string(bb) != c.want {
+			t.Errorf("JSON(%v) == %v, want %v", c.value, string(bb), c.want)
+		} else {
+			var ldt LocalDateTime
+			if err = json.Unmarshal(bb, &ldt); err != nil || ldt != c.value {
+				t.Errorf("JSON(%v) unmarshal got %v %v", c.value, ldt, err)
+			}
+		}
+
+		var buf bytes.Buffer
+		if err = gob.NewEncoder(&buf).Encode(c.value); err != nil {
+			t.Errorf("Gob(%v) encode error %v", c.value, err)
+		} else {
+			var ldt LocalDateTime
+			if err = gob.NewDecoder(&buf).Decode(&ldt); err != nil || ldt != c.value {
+				t.Errorf("Gob(%v) decode got %v %v", c.value, ldt, err)
+			}
+		}
+	}
+
+	var ldt LocalDateTime
+	if ldt.UnmarshalBinary([]byte{}) == nil {
+		t.Errorf("unmarshal no empty data error")
+	}
+	if ldt.UnmarshalBinary([]byte("12345")) == nil {
+		t.Errorf("unmarshal no wrong length error")
+	}
+	if json.Unmarshal([]byte(`"2026-10-16"`), &ldt) == nil {
+		t.Errorf("unmarshal no invalid text error")
+	}
+}
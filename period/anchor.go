@@ -0,0 +1,129 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package period
+
+import (
+	"time"
+)
+
+// CompareAt compares two periods by adding each of them to a specified anchor time.
+// The result is -1 if a ends before b, +1 if a ends after b, and zero if they end at
+// the same time. For example, P1M is shorter than P30D starting on 1st February but
+// is longer than P30D starting on 1st January.
+//
+// Unlike comparisons based on DurationApprox, this is exact because it uses the actual
+// lengths of the months and days that follow the anchor, including any daylight-saving
+// changes in the anchor's location. See AddAt.
+func CompareAt(a, b Period, anchor time.Time) int {
+	ta := a.AddAt(anchor)
+	tb := b.AddAt(anchor)
+	switch {
+	case ta.Before(tb):
+		return -1
+	case ta.After(tb):
+		return 1
+	}
+	return 0
+}
+
+// DurationAt converts a period to the exact duration that it spans when it starts at
+// a specified anchor time, in a specified location. If loc is nil, the anchor's own
+// location is used. For example, P1D is 23 hours long on the day that daylight saving
+// starts in Europe/London, and P1M starting on 1st February 2021 is 28 days long.
+//
+// If the period is greater than approximately 290 years, the result will overflow.
+func (period Period) DurationAt(anchor time.Time, loc *time.Location) time.Duration {
+	if loc != nil {
+		anchor = anchor.In(loc)
+	}
+	return period.AddAt(anchor).Sub(anchor)
+}
+
+// AddAt adds the period to a time, returning the result. This is the same as AddTo
+// except when the years, months or days contain fractions: AddTo approximates these,
+// whereas AddAt uses the actual lengths of the year, month or day that follows the
+// whole part. So P0.5M added to 1st February 2021 gives 15th February.
+//
+// The fields are applied in the same order as AddTo.
+func (period Period) AddAt(t time.Time) time.Time {
+	years, months, days := int(period.years/10), int(period.months/10), int(period.totalDays()/10)
+	base := t.AddDate(years, months, days)
+
+	result := base
+	result = result.Add(fractionOf(period.years, base, t.AddDate(years+sign10(period.years), months, days)))
+	result = result.Add(fractionOf(period.months, base, t.AddDate(years, months+sign10(period.months), days)))
+	result = result.Add(fractionOf(period.totalDays(), base, t.AddDate(years, months, days+sign10(period.totalDays()))))
+
+	return result.Add(totalSecondsE3(period) * time.Millisecond)
+}
+
+// fractionOf gets the fraction of a fixed-point field as a proportion of the
+// actual duration from base to next.
+func fractionOf(field int32, base, next time.Time) time.Duration {
+	frac := absInt32(field % 10)
+	if frac == 0 {
+		return 0
+	}
+	return next.Sub(base) * time.Duration(frac) / 10
+}
+
+func sign10(field int32) int {
+	if field%10 < 0 {
+		return -1
+	}
+	return 1
+}
+
+// NormaliseAt converts the period to an equivalent period that is normalised exactly,
+// using the calendar that follows a specified anchor time. The result has whole months
+// converted to years, the days that make up whole calendar months converted to months,
+// and the elapsed time that makes up whole days converted to days, so
+// P30D becomes P1M2D from 1st February 2021 but P30D from 1st January 2021.
+//
+// The result has the same end point as the original when added to the anchor using
+// AddAt, which uses the actual lengths of any fractional years, months or days. Any
+// weeks are expressed as days; use PreferWeeks if needed. The seconds are truncated to
+// one decimal place.
+func (period Period) NormaliseAt(anchor time.Time) Period {
+	end := period.AddAt(anchor)
+
+	step := 1
+	before := func(a, b time.Time) bool { return !a.After(b) }
+	if end.Before(anchor) {
+		step = -1
+		before = func(a, b time.Time) bool { return !a.Before(b) }
+	}
+
+	// estimate the number of months, then adjust to the largest that fits
+	months := (end.Year()-anchor.Year())*12 + int(end.Month()-anchor.Month())
+	for months != 0 && !before(anchor.AddDate(0, months, 0), end) {
+		months -= step
+	}
+	for before(anchor.AddDate(0, months+step, 0), end) {
+		months += step
+	}
+
+	// likewise for the number of days
+	base := anchor.AddDate(0, months, 0)
+	days := int(end.Sub(base) / (24 * time.Hour))
+	for days != 0 && !before(base.AddDate(0, 0, days), end) {
+		days -= step
+	}
+	for before(base.AddDate(0, 0, days+step), end) {
+		days += step
+	}
+
+	rest := end.Sub(anchor.AddDate(0, months, days))
+	hours := rest / time.Hour
+	minutes := rest % time.Hour / time.Minute
+	seconds := rest % time.Minute / hundredMs
+
+	p64 := &period64{
+		years: int64(months/12) * 10, months: int64(months%12) * 10, days: int64(days) * 10,
+		hours: int64(hours) * 10, minutes: int64(minutes) * 10, seconds: int64(seconds),
+	}
+	result, _ := p64.toPeriod()
+	return result
+}
@@ -0,0 +1,114 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package period
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCompareAt(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		a, b   string
+		anchor time.Time
+		want   int
+	}{
+		{"P1M", "P30D", utc(2021, 2, 1, 0, 0, 0, 0), -1},
+		{"P1M", "P28D", utc(2021, 2, 1, 0, 0, 0, 0), 0},
+		{"P1M", "P29D", utc(2020, 2, 1, 0, 0, 0, 0), 0},
+		{"P1M", "P30D", utc(2021, 1, 1, 0, 0, 0, 0), 1},
+		{"P1M", "P30D", utc(2021, 4, 1, 0, 0, 0, 0), 0},
+		{"P1Y", "P365D", utc(2020, 1, 1, 0, 0, 0, 0), 1},
+		{"P1Y", "P365D", utc(2021, 1, 1, 0, 0, 0, 0), 0},
+		{"P1D", "PT24H", bst(2021, 3, 27, 12, 0, 0, 0), -1},
+		{"P1D", "PT24H", bst(2021, 10, 30, 12, 0, 0, 0), 1},
+		{"P1D", "PT24H", utc(2021, 3, 27, 12, 0, 0, 0), 0},
+		{"-P1M", "-P30D", utc(2021, 3, 1, 0, 0, 0, 0), 1},
+		{"P1M-1D", "P27D", utc(2021, 2, 1, 0, 0, 0, 0), 0},
+	}
+	for i, c := range cases {
+		a, b := MustParse(c.a, false), MustParse(c.b, false)
+		g.Expect(CompareAt(a, b, c.anchor)).To(Equal(c.want), info(i, c.a))
+		g.Expect(CompareAt(b, a, c.anchor)).To(Equal(-c.want), info(i, c.a))
+	}
+}
+
+func TestDurationAt(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value  string
+		anchor time.Time
+		loc    *time.Location
+		want   time.Duration
+	}{
+		{"P1M", utc(2021, 2, 1, 0, 0, 0, 0), nil, 28 * oneDay},
+		{"P1M", utc(2020, 2, 1, 0, 0, 0, 0), nil, 29 * oneDay},
+		{"P1Y", utc(2020, 1, 1, 0, 0, 0, 0), nil, 366 * oneDay},
+		{"P1D", utc(2021, 3, 27, 23, 0, 0, 0), london, 23 * time.Hour},
+		{"P1D", utc(2021, 10, 30, 23, 0, 0, 0), london, 25 * time.Hour},
+		{"P1D", utc(2021, 10, 30, 23, 0, 0, 0), nil, 24 * time.Hour},
+		{"P1DT1H", utc(2021, 3, 27, 23, 0, 0, 0), london, 24 * time.Hour},
+		{"P0.5M", utc(2021, 2, 1, 0, 0, 0, 0), nil, 14 * oneDay},
+		{"P0.5Y", utc(2020, 1, 1, 0, 0, 0, 0), nil, 183 * oneDay},
+		{"P1.5D", utc(2021, 3, 27, 23, 0, 0, 0), london, 23*time.Hour + 12*time.Hour},
+		{"-P1M", utc(2021, 3, 1, 0, 0, 0, 0), nil, -28 * oneDay},
+		{"-P0.5M", utc(2021, 3, 1, 0, 0, 0, 0), nil, -14 * oneDay},
+		{"PT1H30M", utc(2021, 3, 28, 0, 30, 0, 0), london, 90 * time.Minute},
+	}
+	for i, c := range cases {
+		p := MustParse(c.value, false)
+		g.Expect(p.DurationAt(c.anchor, c.loc)).To(Equal(c.want), info(i, c.value))
+	}
+}
+
+func TestAddAtMatchesAddToForWholeFields(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	anchor := bst(2020, 2, 29, 10, 0, 0, 0)
+	for i, v := range []string{"P1Y1M", "P1M-1D", "P1W3DT25H", "-P1Y2M3DT4H5M6.5S", "P2W"} {
+		p := MustParse(v, false)
+		t1, _ := p.AddTo(anchor)
+		g.Expect(p.AddAt(anchor)).To(Equal(t1), info(i, v))
+	}
+}
+
+func TestNormaliseAt(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value  string
+		anchor time.Time
+		want   string
+	}{
+		{"P30D", utc(2021, 2, 1, 0, 0, 0, 0), "P1M2D"},
+		{"P30D", utc(2021, 1, 1, 0, 0, 0, 0), "P30D"},
+		{"P31D", utc(2021, 1, 1, 0, 0, 0, 0), "P1M"},
+		{"P400D", utc(2021, 1, 1, 0, 0, 0, 0), "P1Y1M4D"},
+		{"P2W", utc(2021, 2, 20, 0, 0, 0, 0), "P14D"},
+		{"PT48H", utc(2021, 1, 1, 0, 0, 0, 0), "P2D"},
+		{"PT24H", bst(2021, 3, 27, 12, 0, 0, 0), "P1DT1H"},
+		{"PT24H", bst(2021, 3, 27, 0, 0, 0, 0), "P1D"},
+		{"P1D", bst(2021, 3, 27, 12, 0, 0, 0), "P1D"},
+		{"PT90061.5S", utc(2021, 1, 1, 0, 0, 0, 0), "P1DT1H1M1.5S"},
+		{"P13M", utc(2021, 1, 31, 0, 0, 0, 0), "P1Y1M"},
+		{"P1M-1D", utc(2021, 1, 15, 0, 0, 0, 0), "P30D"},
+		{"-P30D", utc(2021, 3, 1, 0, 0, 0, 0), "-P1M2D"},
+		{"-PT25H", utc(2021, 3, 1, 0, 0, 0, 0), "-P1DT1H"},
+		{"P0D", utc(2021, 3, 1, 0, 0, 0, 0), "P0D"},
+	}
+	for i, c := range cases {
+		p := MustParse(c.value, false)
+		n := p.NormaliseAt(c.anchor)
+		g.Expect(n.String()).To(Equal(c.want), info(i, c.value))
+
+		t1, _ := n.AddTo(c.anchor)
+		g.Expect(t1).To(Equal(p.AddAt(c.anchor)), info(i, c.value))
+	}
+}
@@ -16,6 +16,7 @@ func (period Period) Add(that Period) Period {
 	return Period{
 		period.years + that.years,
 		period.months + that.months,
+		period.weeks + that.weeks,
 		period.days + that.days,
 		period.hours + that.hours,
 		period.minutes + that.minutes,
@@ -33,15 +34,20 @@ func (period Period) Add(that Period) Period {
 // result is precise. However, when years, months or days contains fractions, the result
 // is only an approximation (it assumes that all days are 24 hours and every year is 365.2425
 // days, as per Gregorian calendar rules).
+//
+// The fields are applied in a defined order, which matters for mixed-sign periods: the years
+// and months are applied first, then the days (as per time.AddDate, which normalises the result
+// afterwards), then the hours, minutes and seconds as an elapsed duration. So "P1M-1D" added
+// to 15th January gives 14th February.
 func (period Period) AddTo(t time.Time) (time.Time, bool) {
 	wholeYears := (period.years % 10) == 0
 	wholeMonths := (period.months % 10) == 0
-	wholeDays := (period.days % 10) == 0
+	wholeDays := (period.totalDays() % 10) == 0
 
 	if wholeYears && wholeMonths && wholeDays {
 		// in this case, time.AddDate provides an exact solution
 		stE3 := totalSecondsE3(period)
-		t1 := t.AddDate(int(period.years/10), int(period.months/10), int(period.days/10))
+		t1 := t.AddDate(int(period.years/10), int(period.months/10), int(period.totalDays()/10))
 		return t1.Add(stE3 * time.Millisecond), true
 	}
 
@@ -84,12 +90,13 @@ func (period Period) ScaleWithOverflowCheck(factor float32) (Period, error) {
 
 	y := int64(float32(ap.years) * factor)
 	m := int64(float32(ap.months) * factor)
+	w := int64(float32(ap.weeks) * factor)
 	d := int64(float32(ap.days) * factor)
 	hh := int64(float32(ap.hours) * factor)
 	mm := int64(float32(ap.minutes) * factor)
 	ss := int64(float32(ap.seconds) * factor)
 
-	p64 := &period64{years: y, months: m, days: d, hours: hh, minutes: mm, seconds: ss, neg: neg}
+	p64 := &period64{years: y, months: m, weeks: w, days: d, hours: hh, minutes: mm, seconds: ss, neg: neg}
 	return p64.normalise64(true).toPeriod()
 }
 
@@ -131,6 +131,37 @@ func TestPeriodAddToTime(t *testing.T) {
 	}
 }
 
+func TestMixedSignPeriodAddToTime(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value  string
+		start  time.Time
+		result time.Time
+	}{
+		{"P1M-1D", utc(2021, 1, 15, 0, 0, 0, 0), utc(2021, 2, 14, 0, 0, 0, 0)},
+		{"P1M-1D", utc(2021, 1, 31, 0, 0, 0, 0), utc(2021, 3, 2, 0, 0, 0, 0)},
+		{"-P1M-1D", utc(2021, 3, 15, 0, 0, 0, 0), utc(2021, 2, 16, 0, 0, 0, 0)},
+		{"P1Y-1M", utc(2021, 6, 1, 0, 0, 0, 0), utc(2022, 5, 1, 0, 0, 0, 0)},
+		{"P1DT-1H", utc(2021, 6, 1, 12, 0, 0, 0), utc(2021, 6, 2, 11, 0, 0, 0)},
+		{"PT1H-0.5S", utc(2021, 6, 1, 12, 0, 0, 0), utc(2021, 6, 1, 12, 59, 59, 500)},
+	}
+	for i, c := range cases {
+		t1, prec := MustParse(c.value, false).AddTo(c.start)
+		g.Expect(t1).To(Equal(c.result), info(i, c.value))
+		g.Expect(prec).To(BeTrue(), info(i, c.value))
+	}
+}
+
+func TestMixedSignPeriodAdd(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(MustParse("P1M").Add(MustParse("-P1D")).String()).To(Equal("P1M-1D"))
+	g.Expect(MustParse("P1M-1D").Add(MustParse("P1D")).String()).To(Equal("P1M"))
+	g.Expect(MustParse("P1M-1D").Scale(2).String()).To(Equal("P2M-2D"))
+	g.Expect(MustParse("P1M-1D").Scale(-1).String()).To(Equal("P-1M1D"))
+}
+
 func expectValid(t *testing.T, period Period, hint interface{}) Period {
 	t.Helper()
 	g := NewGomegaWithT(t)
@@ -41,4 +41,10 @@
 //
 // * "PT12M7.5S" is 12 minutes and 7.5 seconds.
 //
+// Where more precision or range is needed, the Nano type holds the seconds with nanosecond
+// precision and each field as an int64. For example, "PT0.000001S" is one microsecond.
+//
+// ParseHuman is a lenient alternative to Parse that also accepts forms such as "3 days 4 hours",
+// "1y 6mo" and Go duration strings such as "2h30m15s". It is used when periods are set via flags.
+//
 package period
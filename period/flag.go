@@ -1,8 +1,10 @@
 package period
 
-// Set enables use of Period by the flag API.
+// Set enables use of Period by the flag API. As well as ISO-8601 strings, it
+// accepts the lenient forms understood by ParseHuman, such as "3 days 4 hours"
+// and "2h30m".
 func (period *Period) Set(p string) error {
-	p2, err := Parse(p)
+	p2, err := ParseHuman(p)
 	if err != nil {
 		return err
 	}
@@ -7,6 +7,7 @@ package period
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/rickb777/plural"
@@ -29,31 +30,43 @@ func (period Period) FormatWithPeriodNames(yearNames, monthNames, weekNames, day
 	period = period.Abs()
 
 	parts := make([]string, 0)
-	parts = appendNonBlank(parts, yearNames.FormatFloat(float10(period.years)))
-	parts = appendNonBlank(parts, monthNames.FormatFloat(float10(period.months)))
+	parts = appendNonBlank(parts, formatField(yearNames, period.years))
+	parts = appendNonBlank(parts, formatField(monthNames, period.months))
 
-	if period.days > 0 || (period.IsZero()) {
+	// weeks are shown here on the basis of the total number of days
+	days := period.totalDays()
+	if days != 0 || (period.IsZero()) {
 		if len(weekNames) > 0 {
-			weeks := period.days / 70
-			mdays := period.days % 70
+			weeks := days / 70
+			mdays := days % 70
 			//fmt.Printf("%v %#v - %d %d\n", period, period, weeks, mdays)
-			if weeks > 0 {
-				parts = appendNonBlank(parts, weekNames.FormatInt(int(weeks)))
+			if weeks != 0 {
+				parts = appendNonBlank(parts, formatField(weekNames, weeks*10))
 			}
-			if mdays > 0 || weeks == 0 {
-				parts = appendNonBlank(parts, dayNames.FormatFloat(float10(mdays)))
+			if mdays != 0 || weeks == 0 {
+				parts = appendNonBlank(parts, formatField(dayNames, mdays))
 			}
 		} else {
-			parts = appendNonBlank(parts, dayNames.FormatFloat(float10(period.days)))
+			parts = appendNonBlank(parts, formatField(dayNames, days))
 		}
 	}
-	parts = appendNonBlank(parts, hourNames.FormatFloat(float10(period.hours)))
-	parts = appendNonBlank(parts, minNames.FormatFloat(float10(period.minutes)))
-	parts = appendNonBlank(parts, secNames.FormatFloat(float10(period.seconds)))
+	parts = appendNonBlank(parts, formatField(hourNames, period.hours))
+	parts = appendNonBlank(parts, formatField(minNames, period.minutes))
+	parts = appendNonBlank(parts, formatField(secNames, period.seconds))
 
 	return strings.Join(parts, ", ")
 }
 
+// formatField formats a fixed-point field. The plurals only handle positive numbers,
+// so the negative fields of mixed-sign periods are formatted as a minus sign followed
+// by the positive form, e.g. "-1 day".
+func formatField(names plural.Plurals, v int32) string {
+	if v < 0 {
+		return "-" + names.FormatFloat(float10(-v))
+	}
+	return names.FormatFloat(float10(v))
+}
+
 func appendNonBlank(parts []string, s string) []string {
 	if s == "" {
 		return parts
@@ -84,7 +97,8 @@ var PeriodMinuteNames = plural.FromZero("", "%v minute", "%v minutes")
 // PeriodSecondNames is as for PeriodDayNames but for seconds.
 var PeriodSecondNames = plural.FromZero("", "%v second", "%v seconds")
 
-// String converts the period to ISO-8601 form.
+// String converts the period to ISO-8601 form. For mixed-sign periods, each
+// negative field has its own sign, e.g. "P1M-1D".
 func (period Period) String() string {
 	return period.toPeriod64("").String()
 }
@@ -103,14 +117,8 @@ func (p64 period64) String() string {
 
 	writeField64(buf, p64.years, byte(Year))
 	writeField64(buf, p64.months, byte(Month))
-
-	if p64.days != 0 {
-		if p64.days%70 == 0 {
-			writeField64(buf, p64.days/7, byte(Week))
-		} else {
-			writeField64(buf, p64.days, byte(Day))
-		}
-	}
+	writeField64(buf, p64.weeks, byte(Week))
+	writeField64(buf, p64.days, byte(Day))
 
 	if p64.hours != 0 || p64.minutes != 0 || p64.seconds != 0 {
 		buf.WriteByte('T')
@@ -123,6 +131,50 @@ func (p64 period64) String() string {
 	return buf.String()
 }
 
+// String converts the period to ISO-8601 form. The seconds are shown with as many
+// decimal places as are needed, up to nine.
+func (n Nano) String() string {
+	if n.IsZero() {
+		return "P0D"
+	}
+
+	a := n
+	buf := &strings.Builder{}
+	if n.Sign() < 0 && !n.IsMixed() {
+		a = n.Negate()
+		buf.WriteByte('-')
+	}
+
+	buf.WriteByte('P')
+
+	writeField64(buf, a.years, byte(Year))
+	writeField64(buf, a.months, byte(Month))
+	writeField64(buf, a.weeks, byte(Week))
+	writeField64(buf, a.days, byte(Day))
+
+	if a.hours != 0 || a.minutes != 0 || a.seconds != 0 || a.nanos != 0 {
+		buf.WriteByte('T')
+	}
+
+	writeField64(buf, a.hours, byte(Hour))
+	writeField64(buf, a.minutes, byte(Minute))
+
+	if a.seconds != 0 || a.nanos != 0 {
+		seconds, nanos := a.seconds, a.nanos
+		if seconds < 0 || nanos < 0 {
+			seconds, nanos = -seconds, -nanos
+			buf.WriteByte('-')
+		}
+		buf.WriteString(strconv.FormatInt(seconds, 10))
+		if nanos != 0 {
+			buf.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", nanos), "0"))
+		}
+		buf.WriteByte(byte(Second))
+	}
+
+	return buf.String()
+}
+
 func writeField64(w io.Writer, field int64, designator byte) {
 	if field != 0 {
 		if field%10 != 0 {
@@ -0,0 +1,321 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package period
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/rickb777/plural"
+)
+
+// HumanNames is a localisable table of the words understood by ParseHuman. It is the
+// inverse of the plurals used by FormatWithPeriodNames: each unit name maps to the
+// period that one such unit represents, so synonyms such as "fortnight" can be included.
+type HumanNames struct {
+	Units   map[string]Period // lower-case unit names and abbreviations, e.g. "day" and "d"
+	Numbers map[string]int    // lower-case words for numbers, e.g. "a" and "an" meaning one
+	Ignore  []string          // lower-case words that are skipped, e.g. "and"
+}
+
+// NewHumanNames builds the unit names from the same plurals used by FormatWithPeriodNames.
+// Each format string such as "%v days" contributes the word "days". Further abbreviations,
+// number words and ignored words can be added to the result as needed.
+func NewHumanNames(yearNames, monthNames, weekNames, dayNames, hourNames, minNames, secNames plural.Plurals) HumanNames {
+	names := HumanNames{
+		Units:   make(map[string]Period),
+		Numbers: make(map[string]int),
+	}
+	names.addPlurals(yearNames, NewYMD(1, 0, 0))
+	names.addPlurals(monthNames, NewYMD(0, 1, 0))
+	names.addPlurals(weekNames, NewYMWD(0, 0, 1, 0))
+	names.addPlurals(dayNames, NewYMD(0, 0, 1))
+	names.addPlurals(hourNames, NewHMS(1, 0, 0))
+	names.addPlurals(minNames, NewHMS(0, 1, 0))
+	names.addPlurals(secNames, NewHMS(0, 0, 1))
+	return names
+}
+
+func (names HumanNames) addPlurals(plurals plural.Plurals, unit Period) {
+	for _, c := range plurals {
+		word := strings.ToLower(strings.TrimSpace(strings.Replace(c.Format, "%v", "", 1)))
+		if word != "" {
+			names.Units[word] = unit
+		}
+	}
+}
+
+// EnglishNames provides the English names used by ParseHuman. As well as the words
+// used by Format, it includes common abbreviations such as "yr", "mo", "wk", "hrs" and
+// "min", the single-letter units of Go durations (so "m" is minutes), and "fortnight".
+var EnglishNames = englishNames()
+
+func englishNames() HumanNames {
+	names := NewHumanNames(PeriodYearNames, PeriodMonthNames, PeriodWeekNames, PeriodDayNames, PeriodHourNames, PeriodMinuteNames, PeriodSecondNames)
+
+	add := func(unit Period, words ...string) {
+		for _, w := range words {
+			names.Units[w] = unit
+		}
+	}
+	add(NewYMD(1, 0, 0), "y", "yr", "yrs")
+	add(NewYMD(0, 1, 0), "mo", "mos", "mth", "mths", "mon", "mons")
+	add(NewYMWD(0, 0, 1, 0), "w", "wk", "wks")
+	add(NewYMWD(0, 0, 2, 0), "fortnight", "fortnights")
+	add(NewYMD(0, 0, 1), "d")
+	add(NewHMS(1, 0, 0), "h", "hr", "hrs")
+	add(NewHMS(0, 1, 0), "m", "min", "mins")
+	add(NewHMS(0, 0, 1), "s", "sec", "secs")
+
+	names.Numbers["a"] = 1
+	names.Numbers["an"] = 1
+	names.Numbers["one"] = 1
+	names.Ignore = []string{"and"}
+	return names
+}
+
+// sub-second units of Go durations, as the number of decimal places they shift seconds by
+var subSecondUnits = map[string]int{"ms": 3, "us": 6, "µs": 6, "μs": 6, "ns": 9}
+
+// smaller field that fractions can be carried into, with the multiplier needed
+var carry = map[byte]struct {
+	field  byte
+	factor int64
+}{
+	'Y': {'M', 12},
+	'W': {'D', 7},
+	'H': {'m', 60},
+	'm': {'S', 60},
+}
+
+// MustParseHuman is as per ParseHuman except that it panics if the string cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustParseHuman(value string, normalise ...bool) Period {
+	p, err := ParseHuman(value, normalise...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// ParseHuman is a lenient parser for periods written the way people type them, using the
+// English names. See HumanNames.Parse.
+func ParseHuman(value string, normalise ...bool) (Period, error) {
+	return EnglishNames.Parse(value, normalise...)
+}
+
+// Parse is a lenient parser for periods written the way people type them. It accepts
+// a sequence of numbers and unit names, such as "3 days 4 hours", "1y 6mo", "1.5 weeks"
+// or "a fortnight", as well as Go duration strings such as "2h30m15s" and "1500ms".
+// Spaces, commas and ignored words between the terms are optional, as is the case of
+// the unit names. ISO-8601 strings such as "P3DT4H" are also accepted, as per Parse.
+//
+// A plus or minus sign can precede the whole value, e.g. "-2h".
+//
+// Fractions are allowed in any term. A fraction with one decimal place is kept in its
+// own field, so "1.5 weeks" is P1.5W. A longer fraction is carried into the next smaller
+// field if that is precise, so "1.25h" is the same as "1h 15m". Otherwise, the fraction
+// is an error, as is anything less than a tenth of a second.
+//
+// By default, the value is normalised as per Parse. Normalisation can be disabled using
+// the optional flag.
+func (names HumanNames) Parse(value string, normalise ...bool) (Period, error) {
+	norm := len(normalise) == 0 || normalise[0]
+
+	s := strings.TrimSpace(value)
+	if s == "" {
+		return Period{}, fmt.Errorf("cannot parse a blank string as a period")
+	}
+
+	unsigned := strings.TrimLeft(s, "+-")
+	if strings.HasPrefix(unsigned, "P") {
+		return ParseWithNormalise(s, norm)
+	}
+
+	neg := false
+	if s[0] == '-' || s[0] == '+' {
+		neg = s[0] == '-'
+		s = strings.TrimSpace(s[1:])
+	}
+
+	if s == "0" {
+		return Period{}, nil
+	}
+
+	result := &period64{input: value, neg: neg}
+	fields := map[byte]*int64{
+		'Y': &result.years, 'M': &result.months, 'W': &result.weeks, 'D': &result.days,
+		'H': &result.hours, 'm': &result.minutes, 'S': &result.seconds,
+	}
+
+	nTerms := 0
+	for {
+		s = names.skip(s)
+		if s == "" {
+			break
+		}
+
+		mantissa, scale, rest, err := names.parseNumber(s, value)
+		if err != nil {
+			return Period{}, err
+		}
+
+		var word string
+		word, s = scanWord(strings.TrimLeft(rest, " "))
+		if word == "" {
+			return Period{}, fmt.Errorf("%s: expected a unit after the number", value)
+		}
+		word = strings.ToLower(word)
+
+		if places, exists := subSecondUnits[word]; exists {
+			err = addTerm(result, fields, 'S', mantissa, scale+places, value)
+		} else if unit, exists := names.Units[word]; exists {
+			err = addUnit(result, fields, unit, mantissa, scale, value)
+		} else {
+			return Period{}, fmt.Errorf("%s: unknown unit %q", value, word)
+		}
+		if err != nil {
+			return Period{}, err
+		}
+		nTerms++
+	}
+
+	if nTerms == 0 {
+		return Period{}, fmt.Errorf("%s: expected a number and a unit", value)
+	}
+
+	if norm {
+		result = result.normalise64(true)
+	}
+
+	return result.toPeriod()
+}
+
+// skip drops any leading spaces, commas and ignored words.
+func (names HumanNames) skip(s string) string {
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		word, rest := scanWord(s)
+		if word == "" || !names.ignored(strings.ToLower(word)) {
+			return s
+		}
+		s = rest
+	}
+}
+
+func (names HumanNames) ignored(word string) bool {
+	for _, w := range names.Ignore {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNumber parses either a decimal number or a number word, returning the number
+// as a mantissa and the count of its decimal places. As with ISO-8601, the decimal
+// separator can be '.' or ','; a comma that is not followed by a digit separates terms.
+func (names HumanNames) parseNumber(s, original string) (mantissa int64, scale int, rest string, err error) {
+	i := 0
+	for i < len(s) && ('0' <= s[i] && s[i] <= '9' || s[i] == '.' ||
+		s[i] == ',' && i+1 < len(s) && '0' <= s[i+1] && s[i+1] <= '9') {
+		i++
+	}
+
+	if i == 0 {
+		word, rest := scanWord(s)
+		if n, exists := names.Numbers[strings.ToLower(word)]; exists {
+			return int64(n), 0, rest, nil
+		}
+		return 0, 0, "", fmt.Errorf("%s: expected a number but found %q", original, word)
+	}
+
+	number := strings.ReplaceAll(s[:i], ",", ".")
+	dot := strings.IndexByte(number, '.')
+	if dot >= 0 {
+		scale = len(number) - dot - 1
+		number = number[:dot] + number[dot+1:]
+	}
+
+	if number == "" || strings.IndexByte(number, '.') >= 0 || len(number) > 15 {
+		return 0, 0, "", fmt.Errorf("%s: %q is not a valid number", original, s[:i])
+	}
+
+	for _, c := range number {
+		mantissa = mantissa*10 + int64(c-'0')
+	}
+	return mantissa, scale, s[i:], nil
+}
+
+// scanWord gets the leading run of letters.
+func scanWord(s string) (word, rest string) {
+	for i, c := range s {
+		if !unicode.IsLetter(c) {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+// addUnit adds a number of units to the result.
+func addUnit(result *period64, fields map[byte]*int64, unit Period, mantissa int64, scale int, original string) error {
+	for _, f := range []struct {
+		des byte
+		v   int32
+	}{
+		{'Y', unit.years}, {'M', unit.months}, {'W', unit.weeks}, {'D', unit.days},
+		{'H', unit.hours}, {'m', unit.minutes}, {'S', unit.seconds},
+	} {
+		if f.v != 0 {
+			// the unit fields are fixed-point with one decimal place
+			if err := addTerm(result, fields, f.des, mantissa*int64(f.v), scale+1, original); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addTerm adds mantissa × 10^-scale to a field, carrying any excess fraction into
+// the next smaller field.
+func addTerm(result *period64, fields map[byte]*int64, des byte, mantissa int64, scale int, original string) error {
+	// the fields are fixed-point with one decimal place
+	scale--
+
+	divisor := int64(1)
+	for ; scale > 0; scale-- {
+		divisor *= 10
+		if divisor > 1e15 {
+			return fmt.Errorf("%s: too many decimal places", original)
+		}
+	}
+	for ; scale < 0; scale++ {
+		mantissa *= 10
+	}
+
+	if mantissa%divisor == 0 {
+		*fields[des] += mantissa / divisor
+		return nil
+	}
+
+	next, exists := carry[des]
+	if !exists {
+		return fmt.Errorf("%s: too many decimal places", original)
+	}
+
+	// keep the whole part and carry all of the fraction
+	whole := divisor * 10
+	*fields[des] += mantissa / whole * 10
+	return addTerm(result, fields, next.field, mantissa%whole*next.factor, countDigits(whole)-1, original)
+}
+
+func countDigits(divisor int64) int {
+	n := 0
+	for ; divisor > 1; divisor /= 10 {
+		n++
+	}
+	return n + 1
+}
@@ -0,0 +1,125 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package period
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/rickb777/plural"
+)
+
+func TestParseHuman(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value     string
+		normalise bool
+		expected  string
+	}{
+		{"0", false, "P0D"},
+		{"3 days 4 hours", false, "P3DT4H"},
+		{"3 days, 4 hours and 5 minutes", false, "P3DT4H5M"},
+		{"1 year 1 day", false, "P1Y1D"},
+		{"1y 6mo", false, "P1Y6M"},
+		{"1y6mo", false, "P1Y6M"},
+		{"2 Weeks", false, "P2W"},
+		{"1.5 weeks", false, "P1.5W"},
+		{"a fortnight", false, "P2W"},
+		{"2 fortnights", false, "P4W"},
+		{"an hour", false, "PT1H"},
+		{"1 wk 2 d", false, "P1W2D"},
+		{"2h30m15s", false, "PT2H30M15S"},
+		{"1.25h", false, "PT1H15M"},
+		{"1.255h", false, "PT1H15.3M"},
+		{"1.2525h", false, "PT1H15M9S"},
+		{"1,5h", false, "PT1.5H"},
+		{"1,25 hours", false, "PT1H15M"},
+		{"2 hours, 30 minutes", false, "PT2H30M"},
+		{"0.5y", false, "P0.5Y"},
+		{"0.25y", false, "P3M"},
+		{"1500ms", false, "PT1.5S"},
+		{"90m", false, "PT90M"},
+		{"90m", true, "PT1H30M"},
+		{"-1h30m", false, "-PT1H30M"},
+		{"+ 5 secs", false, "PT5S"},
+		{"  10 min  ", false, "PT10M"},
+		{"P1Y2M", false, "P1Y2M"},
+		{"-P1D", false, "-P1D"},
+		{"PT90M", true, "PT1H30M"},
+	}
+	for i, c := range cases {
+		p, err := ParseHuman(c.value, c.normalise)
+		g.Expect(err).NotTo(HaveOccurred(), info(i, c.value))
+		g.Expect(p.String()).To(Equal(c.expected), info(i, c.value))
+	}
+}
+
+func TestParseHumanErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value    string
+		expected string
+	}{
+		{"", "cannot parse a blank string as a period"},
+		{"-", "-: expected a number and a unit"},
+		{"3", "3: expected a unit after the number"},
+		{"days", `days: expected a number but found "days"`},
+		{"3 parsecs", `3 parsecs: unknown unit "parsecs"`},
+		{"1.2.3h", `1.2.3h: "1.2.3" is not a valid number`},
+		{"1,2.3h", `1,2.3h: "1,2.3" is not a valid number`},
+		{"1,h", "1,h: expected a unit after the number"},
+		{"1.25d", "1.25d: too many decimal places"},
+		{"1ns", "1ns: too many decimal places"},
+		{"PT1X", "PT1X: expected a number not 'X'"},
+		{"2147483648 years", "2147483648 years: integer overflow occurred in years"},
+	}
+	for i, c := range cases {
+		_, err := ParseHuman(c.value)
+		g.Expect(err).To(HaveOccurred(), info(i, c.value))
+		g.Expect(err.Error()).To(Equal(c.expected), info(i, c.value))
+	}
+}
+
+func TestParseHumanWithNames(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	french := NewHumanNames(
+		plural.FromZero("", "%v an", "%v ans"),
+		plural.FromZero("", "%v mois", "%v mois"),
+		plural.FromZero("", "%v semaine", "%v semaines"),
+		plural.FromZero("", "%v jour", "%v jours"),
+		plural.FromZero("", "%v heure", "%v heures"),
+		plural.FromZero("", "%v minute", "%v minutes"),
+		plural.FromZero("", "%v seconde", "%v secondes"),
+	)
+	french.Numbers["un"] = 1
+	french.Numbers["une"] = 1
+	french.Ignore = []string{"et"}
+
+	g.Expect(french.Parse("2 ans et 3 mois", false)).To(Equal(NewYMD(2, 3, 0)))
+	g.Expect(french.Parse("une semaine", false)).To(Equal(NewYMWD(0, 0, 1, 0)))
+	g.Expect(french.Parse("un jour 4 heures", false)).To(Equal(New(0, 0, 1, 4, 0, 0)))
+
+	_, err := french.Parse("2 days")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestPeriodFlagSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var p Period
+	g.Expect(p.Set("P1DT2H")).To(Succeed())
+	g.Expect(p).To(Equal(New(0, 0, 1, 2, 0, 0)))
+
+	g.Expect(p.Set("1 day 2 hours")).To(Succeed())
+	g.Expect(p).To(Equal(New(0, 0, 1, 2, 0, 0)))
+
+	g.Expect(p.Set("45m")).To(Succeed())
+	g.Expect(p).To(Equal(NewHMS(0, 45, 0)))
+
+	g.Expect(p.Set("3 lightyears")).NotTo(Succeed())
+}
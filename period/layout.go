@@ -0,0 +1,325 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package period
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LayoutOption alters the behaviour of FormatLayout. Options can be combined.
+type LayoutOption int
+
+const (
+	// SuppressZero omits each component that is zero, along with the literal text that
+	// follows it (up to the next placeholder). Any spaces or punctuation at the end of that
+	// text are treated as a separator, which is only shown between two components that are
+	// both shown; at the end of the layout, they are always shown. So "(%dd %Hh %Mm)" shows
+	// PT2H as "(2h)". If every component is zero, the last one is kept.
+	SuppressZero LayoutOption = 1 << iota
+
+	// Carry spreads the whole period across the components shown in the layout, so that
+	// the largest one takes any overflow and the smallest one takes any remainder. So
+	// "%H:%02M" shows P1DT2H30M as "26:30". Where months or years are carried into smaller
+	// units, they are estimated as per Gregorian calendar rules.
+	Carry
+)
+
+// lengths of each unit in seconds, as used for totals and carrying
+var layoutUnits = []struct {
+	verb    byte
+	seconds int64
+}{
+	{'Y', 31556952}, // 365.2425 days
+	{'m', 2629746},  // 1/12 of a year
+	{'W', 7 * 86400},
+	{'d', 86400},
+	{'H', 3600},
+	{'M', 60},
+	{'S', 1},
+}
+
+// FormatLayout formats the period according to a layout containing placeholders for
+// each component, which makes it possible to show countdowns, video lengths and so on in
+// different styles. For example, "%dd %Hh %Mm" shows P1DT2H3M as "1d 2h 3m" and
+// "%02H:%02M:%02S" shows PT1H2M3S as "01:02:03".
+//
+// The placeholders are
+//
+//     %Y  years
+//     %m  months
+//     %W  weeks
+//     %d  days (including any weeks, unless %W is also used)
+//     %H  hours
+//     %M  minutes
+//     %S  seconds
+//     %%  a literal percent sign
+//
+// A width can be given after the percent sign, e.g. "%2H" pads the hours with spaces
+// to two digits and "%02H" pads them with zeros. Any fraction is shown after a decimal
+// point, e.g. "7.5".
+//
+// A 't' after the percent sign (and any width) gives a total instead, so "%tH" is the
+// total number of hours in the period, truncated to a whole number. Months and years are
+// estimated as per Gregorian calendar rules and days are assumed to be 24 hours long.
+//
+// Without any options, each placeholder shows its own field as it is stored, so
+// unnormalised periods such as PT90M show 90 minutes. Options SuppressZero and Carry
+// alter this.
+//
+// Negative periods are shown with a leading minus sign. In mixed-sign periods, each
+// negative component has its own minus sign.
+func (period Period) FormatLayout(layout string, options ...LayoutOption) string {
+	var opts LayoutOption
+	for _, o := range options {
+		opts |= o
+	}
+
+	parts := parseLayout(layout)
+
+	prefix := ""
+	if period.IsNegative() && !period.IsMixed() {
+		prefix = "-"
+		period = period.Negate()
+	}
+
+	values := period.layoutValues(parts, opts&Carry != 0)
+
+	last := -1
+	if opts&SuppressZero != 0 {
+		for i, p := range parts {
+			if p.verb != 0 && values[i] != 0 {
+				last = i
+			}
+		}
+		if last < 0 {
+			// everything is zero, so keep the last component
+			for i, p := range parts {
+				if p.verb != 0 {
+					last = i
+				}
+			}
+		}
+	}
+
+	buf := &strings.Builder{}
+	buf.WriteString(prefix)
+
+	if opts&SuppressZero == 0 {
+		for i, p := range parts {
+			if p.verb != 0 {
+				buf.WriteString(formatLayoutValue(values[i], p.width, p.zero))
+			}
+			buf.WriteString(p.literal)
+		}
+		return buf.String()
+	}
+
+	buf.WriteString(parts[0].literal)
+	separator, shown := "", false
+	for i := 1; i < len(parts); i++ {
+		p := parts[i]
+		text, sep := splitSeparator(p.literal)
+		final := i == len(parts)-1
+
+		if values[i] == 0 && i != last {
+			if final {
+				// the punctuation at the end of the layout is always shown
+				buf.WriteString(sep)
+			}
+			continue
+		}
+
+		if shown {
+			buf.WriteString(separator)
+		}
+		buf.WriteString(formatLayoutValue(values[i], p.width, p.zero))
+		if final {
+			text = p.literal
+		}
+		buf.WriteString(text)
+		separator, shown = sep, true
+	}
+	return buf.String()
+}
+
+// splitSeparator splits literal text into the text itself and the trailing spaces and
+// punctuation that separate it from the next component.
+func splitSeparator(literal string) (text, separator string) {
+	trimmed := strings.TrimRightFunc(literal, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+	return trimmed, literal[len(trimmed):]
+}
+
+// layoutPart is a placeholder followed by literal text. The first part may have
+// only literal text, in which case verb is zero.
+type layoutPart struct {
+	verb    byte
+	total   bool
+	width   int
+	zero    bool
+	literal string
+}
+
+func parseLayout(layout string) []layoutPart {
+	parts := []layoutPart{{}}
+	current := &parts[0]
+	literal := &strings.Builder{}
+
+	for i := 0; i < len(layout); i++ {
+		c := layout[i]
+		if c != '%' || i+1 == len(layout) {
+			literal.WriteByte(c)
+			continue
+		}
+
+		j := i + 1
+		if layout[j] == '%' {
+			literal.WriteByte('%')
+			i = j
+			continue
+		}
+
+		p := layoutPart{}
+		if layout[j] == '0' {
+			p.zero = true
+			j++
+		}
+		for j < len(layout) && isDigit(rune(layout[j])) {
+			p.width = p.width*10 + int(layout[j]-'0')
+			j++
+		}
+		if j < len(layout) && layout[j] == 't' {
+			p.total = true
+			j++
+		}
+
+		if j == len(layout) || strings.IndexByte("YmWdHMS", layout[j]) < 0 {
+			// not a placeholder, so it is kept verbatim
+			literal.WriteString(layout[i:minInt(j+1, len(layout))])
+			i = j
+			continue
+		}
+
+		p.verb = layout[j]
+		current.literal = literal.String()
+		literal.Reset()
+		parts = append(parts, p)
+		current = &parts[len(parts)-1]
+		i = j
+	}
+
+	current.literal = literal.String()
+	return parts
+}
+
+// layoutValues gets the fixed-point value for each part of the layout.
+func (period Period) layoutValues(parts []layoutPart, carry bool) []int64 {
+	// total in tenths of a second
+	total := int64(period.years)*31556952 + int64(period.months)*2629746 +
+		int64(period.totalDays())*86400 + int64(period.hours)*3600 +
+		int64(period.minutes)*60 + int64(period.seconds)
+
+	shown := make(map[byte]bool)
+	for _, p := range parts {
+		if p.verb != 0 && !p.total {
+			shown[p.verb] = true
+		}
+	}
+
+	carried := make(map[byte]int64)
+	if carry {
+		remaining := total
+		smallest := byte(0)
+		for _, u := range layoutUnits {
+			if shown[u.verb] {
+				smallest = u.verb
+			}
+		}
+		for _, u := range layoutUnits {
+			if !shown[u.verb] {
+				continue
+			}
+			if u.verb == smallest {
+				carried[u.verb] = remaining / u.seconds
+				break
+			}
+			n := remaining / (u.seconds * 10)
+			carried[u.verb] = n * 10
+			remaining -= n * u.seconds * 10
+		}
+	}
+
+	values := make([]int64, len(parts))
+	for i, p := range parts {
+		switch {
+		case p.verb == 0:
+		case p.total:
+			for _, u := range layoutUnits {
+				if u.verb == p.verb {
+					values[i] = total / (u.seconds * 10) * 10
+				}
+			}
+		case carry:
+			values[i] = carried[p.verb]
+		default:
+			values[i] = period.layoutField(p.verb, shown['W'])
+		}
+	}
+	return values
+}
+
+func (period Period) layoutField(verb byte, weeksShown bool) int64 {
+	switch verb {
+	case 'Y':
+		return int64(period.years)
+	case 'm':
+		return int64(period.months)
+	case 'W':
+		return int64(period.weeks)
+	case 'd':
+		if weeksShown {
+			return int64(period.days)
+		}
+		return int64(period.totalDays())
+	case 'H':
+		return int64(period.hours)
+	case 'M':
+		return int64(period.minutes)
+	}
+	return int64(period.seconds)
+}
+
+// formatLayoutValue formats a fixed-point value, padding the integer part if required.
+func formatLayoutValue(v int64, width int, zero bool) string {
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	s := strconv.FormatInt(v/10, 10)
+	if zero && len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+	s = sign + s
+	if len(s) < width {
+		s = strings.Repeat(" ", width-len(s)) + s
+	}
+
+	if v%10 != 0 {
+		s = s + "." + strconv.FormatInt(v%10, 10)
+	}
+	return s
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
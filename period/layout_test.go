@@ -0,0 +1,76 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package period
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFormatLayout(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		period   string
+		layout   string
+		options  []LayoutOption
+		expected string
+	}{
+		{"P1DT2H3M", "%dd %Hh %Mm", nil, "1d 2h 3m"},
+		{"PT1H2M3S", "%02H:%02M:%02S", nil, "01:02:03"},
+		{"PT1H2M3S", "%2H:%2M:%2S", nil, " 1: 2: 3"},
+		{"PT12M7.5S", "%M:%02S", nil, "12:07.5"},
+		{"P1Y2M3W4D", "%Y years, %m months, %W weeks, %d days", nil, "1 years, 2 months, 3 weeks, 4 days"},
+		{"P1W4D", "%d days", nil, "11 days"},
+		{"PT90M", "%H:%02M", nil, "0:90"},
+		{"-PT1H30M", "%H:%02M", nil, "-1:30"},
+		{"P1M-1D", "%m month, %d days", nil, "1 month, -1 days"},
+		{"PT5M", "100%% in %M min", nil, "100% in 5 min"},
+		{"PT5M", "%x %M%", nil, "%x 5%"},
+		{"PT5M", "no placeholders", nil, "no placeholders"},
+
+		// totals
+		{"P1DT2H30M", "%tH hours", nil, "26 hours"},
+		{"P1DT2H30M", "%tM", nil, "1590"},
+		{"PT1H2M3S", "%tS", nil, "3723"},
+		{"P2W3D", "%tW weeks or %td days", nil, "2 weeks or 17 days"},
+		{"P1Y", "%tm", nil, "12"},
+		{"P1Y", "%td", nil, "365"},
+		{"-P1DT1H", "%tH", nil, "-25"},
+		{"P1DT2H30M", "%tH:%02M", nil, "26:30"},
+
+		// suppress zero
+		{"PT2H", "%dd %Hh %Mm", []LayoutOption{SuppressZero}, "2h"},
+		{"P1DT5M", "%dd %Hh %Mm", []LayoutOption{SuppressZero}, "1d 5m"},
+		{"P0D", "%dd %Hh %Mm", []LayoutOption{SuppressZero}, "0m"},
+		{"PT1H", "%H:%02M", []LayoutOption{SuppressZero}, "1"},
+		{"P3D", "took %d days, %H hours", []LayoutOption{SuppressZero}, "took 3 days"},
+		{"PT1H", "(%H hours)", []LayoutOption{SuppressZero}, "(1 hours)"},
+		{"PT1H", "%H hrs.", []LayoutOption{SuppressZero}, "1 hrs."},
+		{"PT2H", "(%dd %Hh %Mm)", []LayoutOption{SuppressZero}, "(2h)"},
+		{"PT5M", "(%dd %Hh %Mm)", []LayoutOption{SuppressZero}, "(5m)"},
+		{"P1D", "%dd, %Hh.", []LayoutOption{SuppressZero}, "1d."},
+		{"P1DT5M", "[%dd; %Hh; %Mm]", []LayoutOption{SuppressZero}, "[1d; 5m]"},
+		{"P0D", "(%H hrs)", []LayoutOption{SuppressZero}, "(0 hrs)"},
+		{"-PT1H", "%H hrs.", []LayoutOption{SuppressZero}, "-1 hrs."},
+
+		// carry
+		{"P1DT2H30M", "%H:%02M", []LayoutOption{Carry}, "26:30"},
+		{"PT90M", "%H:%02M", []LayoutOption{Carry}, "1:30"},
+		{"PT90M", "%02H:%02M:%02S", []LayoutOption{Carry}, "01:30:00"},
+		{"PT3661.5S", "%H:%02M:%02S", []LayoutOption{Carry}, "1:01:01.5"},
+		{"PT90M", "%H", []LayoutOption{Carry}, "1.5"},
+		{"P1Y", "%m months", []LayoutOption{Carry}, "12 months"},
+		{"P2W3D", "%dd", []LayoutOption{Carry}, "17d"},
+		{"P1W2DT48H", "%Ww %dd", []LayoutOption{Carry}, "1w 4d"},
+		{"-PT90M", "%H:%02M", []LayoutOption{Carry}, "-1:30"},
+		{"PT25H", "%dd %Hh %Mm", []LayoutOption{Carry, SuppressZero}, "1d 1h"},
+	}
+	for i, c := range cases {
+		p := MustParse(c.period, false)
+		g.Expect(p.FormatLayout(c.layout, c.options...)).To(Equal(c.expected), info(i, c.period, c.layout))
+	}
+}
@@ -32,3 +32,31 @@ func (period *Period) UnmarshalText(data []byte) (err error) {
 	}
 	return err
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// This also provides support for gob encoding.
+func (n Nano) MarshalBinary() ([]byte, error) {
+	return n.MarshalText()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// This also provides support for gob decoding.
+func (n *Nano) UnmarshalBinary(data []byte) error {
+	return n.UnmarshalText(data)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for Nano periods.
+// This also provides support for JSON encoding.
+func (n Nano) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Nano periods.
+// This also provides support for JSON decoding.
+func (n *Nano) UnmarshalText(data []byte) (err error) {
+	u, err := ParseNano(string(data), false)
+	if err == nil {
+		*n = u
+	}
+	return err
+}
@@ -63,6 +63,7 @@ func TestPeriodJSONMarshalling(t *testing.T) {
 		{New(0, 0, 1, 0, 0, 0), `"P1D"`},
 		{New(0, 1, 0, 0, 0, 0), `"P1M"`},
 		{New(1, 0, 0, 0, 0, 0), `"P1Y"`},
+		{New(0, 1, -1, 0, 0, 0), `"P1M-1D"`},
 	}
 	for i, c := range cases {
 		var p Period
@@ -93,6 +94,8 @@ func TestPeriodTextMarshalling(t *testing.T) {
 		{New(0, 0, 1, 0, 0, 0), "P1D"},
 		{New(0, 1, 0, 0, 0, 0), "P1M"},
 		{New(1, 0, 0, 0, 0, 0), "P1Y"},
+		{New(0, 1, -1, 0, 0, 0), "P1M-1D"},
+		{New(0, 0, 2, -3, 0, 0), "P2DT-3H"},
 	}
 	for i, c := range cases {
 		var p Period
@@ -0,0 +1,400 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package period
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const oneE8 = 100000000
+const oneE9 = 1000000000
+
+// Nano holds a period of time like Period does, but with higher precision and a much
+// wider range. Like Period, it provides conversion to/from ISO-8601 representations.
+//
+// The years, months, weeks, days, hours and minutes are held with one decimal place, as for Period,
+// but using int64 so that the range of each is approximately ± 9.2 * 10^17. The seconds are
+// held with nanosecond precision (nine decimal places) and have the full int64 range of
+// whole seconds. So, for example, "PT1.5S", "PT0.000001S" and "P100000Y" are all exact.
+//
+// As with Period, the fields usually have the same sign but mixed-sign periods are also
+// allowed. The zero value is a zero-length period. Nano values can be compared using ==.
+//
+// Every Period can be converted to a Nano without loss (see Period.Nano); the converse is
+// possible only when the value is within the range and precision of Period (see Nano.Period).
+type Nano struct {
+	years, months, weeks, days, hours, minutes int64 // fixed-point one decimal place
+	seconds                                    int64 // whole seconds
+	nanos                                      int32 // fraction of a second, with the same sign as seconds
+}
+
+// NewNano creates a simple period without any fractional parts except for the nanoseconds.
+// The fields are initialised verbatim without any normalisation; e.g. 120 seconds will not
+// become 2 minutes. Use the Normalise method if you need to.
+//
+// The parameters may have different signs, giving a mixed-sign period. The nanoseconds
+// are added to the seconds, so may exceed one second.
+func NewNano(years, months, days, hours, minutes, seconds, nanoseconds int64) Nano {
+	s, ns := carryNanos(seconds, nanoseconds)
+	return Nano{years * 10, months * 10, 0, days * 10, hours * 10, minutes * 10, s, ns}
+}
+
+// carryNanos moves whole seconds out of the nanoseconds and ensures that the
+// seconds and nanoseconds have the same sign.
+func carryNanos(seconds, nanos int64) (int64, int32) {
+	seconds += nanos / oneE9
+	nanos = nanos % oneE9
+
+	if seconds > 0 && nanos < 0 {
+		seconds--
+		nanos += oneE9
+	} else if seconds < 0 && nanos > 0 {
+		seconds++
+		nanos -= oneE9
+	}
+
+	return seconds, int32(nanos)
+}
+
+// NanoOf converts a time duration to a Nano period. Unlike NewOf, this is always precise:
+// the result contains only hours, minutes and seconds, and any fraction of a second is
+// retained.
+func NanoOf(duration time.Duration) Nano {
+	hours := duration / time.Hour
+	minutes := duration % time.Hour / time.Minute
+	seconds := duration % time.Minute / time.Second
+	nanos := duration % time.Second
+	return Nano{0, 0, 0, 0, int64(hours) * 10, int64(minutes) * 10, int64(seconds), int32(nanos)}
+}
+
+// Nano converts a Period to the equivalent Nano value. This is always lossless.
+func (period Period) Nano() Nano {
+	return Nano{
+		int64(period.years), int64(period.months), int64(period.weeks), int64(period.days),
+		int64(period.hours), int64(period.minutes),
+		int64(period.seconds / 10), int32(period.seconds%10) * oneE8,
+	}
+}
+
+// Period converts the value to a Period. An error is returned if this cannot be done
+// without loss, i.e. if a field is outside the range of Period or if the seconds have
+// more precision than one decimal place.
+func (n Nano) Period() (Period, error) {
+	if n.nanos%oneE8 != 0 {
+		return Period{}, fmt.Errorf("%s: precision would be lost in seconds", n)
+	}
+
+	if n.seconds > math.MaxInt32/10 || n.seconds < math.MinInt32/10 {
+		return Period{}, fmt.Errorf("%s: integer overflow occurred in seconds", n)
+	}
+
+	p64 := &period64{
+		years: n.years, months: n.months, weeks: n.weeks, days: n.days,
+		hours: n.hours, minutes: n.minutes, seconds: n.seconds*10 + int64(n.nanos/oneE8),
+		input: n.String(),
+	}
+
+	return p64.toPeriod()
+}
+
+// IsZero returns true if applied to a zero-length period.
+func (n Nano) IsZero() bool {
+	return n == Nano{}
+}
+
+// IsPositive returns true if the period is greater than zero. For mixed-sign periods, see Sign.
+func (n Nano) IsPositive() bool {
+	return n.Sign() > 0
+}
+
+// IsNegative returns true if the period is less than zero. For mixed-sign periods, see Sign.
+func (n Nano) IsNegative() bool {
+	return n.Sign() < 0
+}
+
+// IsMixed returns true if the period has both positive and negative fields, such as "P1M-1D".
+func (n Nano) IsMixed() bool {
+	return mixedSigns(n.years, n.months, n.weeks, n.days, n.hours, n.minutes, n.seconds, int64(n.nanos))
+}
+
+// Sign returns +1 for positive periods and -1 for negative periods. If the period is zero, it returns zero.
+// For mixed-sign periods, the result is the sign of the approximate duration, as for Period.Sign.
+func (n Nano) Sign() int {
+	pos := n.years > 0 || n.months > 0 || n.weeks > 0 || n.days > 0 ||
+		n.hours > 0 || n.minutes > 0 || n.seconds > 0 || n.nanos > 0
+	neg := n.years < 0 || n.months < 0 || n.weeks < 0 || n.days < 0 ||
+		n.hours < 0 || n.minutes < 0 || n.seconds < 0 || n.nanos < 0
+
+	switch {
+	case pos && neg:
+		// float64 avoids overflow for the widest periods
+		seconds := float64(n.years)*3155695.2 + float64(n.months)*262974.6 + float64(n.weeks*7+n.days)*8640 +
+			float64(n.hours)*360 + float64(n.minutes)*6 + float64(n.seconds) + float64(n.nanos)/oneE9
+		if seconds > 0 {
+			return 1
+		} else if seconds < 0 {
+			return -1
+		}
+		return 0
+	case neg:
+		return -1
+	case pos:
+		return 1
+	}
+	return 0
+}
+
+// OnlyYMD returns a new period with only the year, month and day fields. The hour,
+// minute and second fields are zeroed.
+func (n Nano) OnlyYMD() Nano {
+	return Nano{n.years, n.months, n.weeks, n.days, 0, 0, 0, 0}
+}
+
+// OnlyHMS returns a new period with only the hour, minute and second fields. The year,
+// month and day fields are zeroed.
+func (n Nano) OnlyHMS() Nano {
+	return Nano{0, 0, 0, 0, n.hours, n.minutes, n.seconds, n.nanos}
+}
+
+// Abs converts a negative period to a positive one.
+func (n Nano) Abs() Nano {
+	if n.IsNegative() {
+		return n.Negate()
+	}
+	return n
+}
+
+// Negate changes the sign of the period.
+func (n Nano) Negate() Nano {
+	return Nano{-n.years, -n.months, -n.weeks, -n.days, -n.hours, -n.minutes, -n.seconds, -n.nanos}
+}
+
+// Add adds two periods together. Use this method along with Negate in order to subtract periods.
+// The result is not normalised.
+func (n Nano) Add(that Nano) Nano {
+	seconds, nanos := carryNanos(n.seconds+that.seconds, int64(n.nanos)+int64(that.nanos))
+	return Nano{
+		n.years + that.years,
+		n.months + that.months,
+		n.weeks + that.weeks,
+		n.days + that.days,
+		n.hours + that.hours,
+		n.minutes + that.minutes,
+		seconds,
+		nanos,
+	}
+}
+
+// Years gets the whole number of years in the period.
+// The result is the number of years and does not include any other field.
+func (n Nano) Years() int64 {
+	return n.years / 10
+}
+
+// YearsFloat gets the number of years in the period, including a fraction if any is present.
+// The result is the number of years and does not include any other field.
+func (n Nano) YearsFloat() float64 {
+	return float64(n.years) / 10
+}
+
+// Months gets the whole number of months in the period.
+// The result is the number of months and does not include any other field.
+func (n Nano) Months() int64 {
+	return n.months / 10
+}
+
+// MonthsFloat gets the number of months in the period.
+// The result is the number of months and does not include any other field.
+func (n Nano) MonthsFloat() float64 {
+	return float64(n.months) / 10
+}
+
+// Days gets the whole number of days in the period. This includes the
+// number of weeks but does not include any other field.
+func (n Nano) Days() int64 {
+	return (n.weeks*7 + n.days) / 10
+}
+
+// DaysFloat gets the number of days in the period. This includes the
+// number of weeks but does not include any other field.
+func (n Nano) DaysFloat() float64 {
+	return float64(n.weeks*7+n.days) / 10
+}
+
+// ExplicitWeeks gets the whole number of weeks held in the weeks field.
+// Unlike Days, this does not include the days field.
+func (n Nano) ExplicitWeeks() int64 {
+	return n.weeks / 10
+}
+
+// ExplicitDays gets the whole number of days held in the days field.
+// Unlike Days, this does not include the weeks field.
+func (n Nano) ExplicitDays() int64 {
+	return n.days / 10
+}
+
+// Hours gets the whole number of hours in the period.
+// The result is the number of hours and does not include any other field.
+func (n Nano) Hours() int64 {
+	return n.hours / 10
+}
+
+// HoursFloat gets the number of hours in the period.
+// The result is the number of hours and does not include any other field.
+func (n Nano) HoursFloat() float64 {
+	return float64(n.hours) / 10
+}
+
+// Minutes gets the whole number of minutes in the period.
+// The result is the number of minutes and does not include any other field.
+func (n Nano) Minutes() int64 {
+	return n.minutes / 10
+}
+
+// MinutesFloat gets the number of minutes in the period.
+// The result is the number of minutes and does not include any other field.
+func (n Nano) MinutesFloat() float64 {
+	return float64(n.minutes) / 10
+}
+
+// Seconds gets the whole number of seconds in the period.
+// The result is the number of seconds and does not include any other field.
+func (n Nano) Seconds() int64 {
+	return n.seconds
+}
+
+// SecondsFloat gets the number of seconds in the period, including the fraction.
+// The result is the number of seconds and does not include any other field.
+func (n Nano) SecondsFloat() float64 {
+	return float64(n.seconds) + float64(n.nanos)/oneE9
+}
+
+// Nanoseconds gets the fraction of a second in the period, as a number of nanoseconds.
+// The result has the same sign as the period.
+func (n Nano) Nanoseconds() int64 {
+	return int64(n.nanos)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// DurationApprox converts a period to the equivalent duration in nanoseconds.
+// See Duration.
+func (n Nano) DurationApprox() time.Duration {
+	d, _ := n.Duration()
+	return d
+}
+
+// Duration converts a period to the equivalent duration in nanoseconds.
+// A flag is also returned that is true when the conversion was precise and false otherwise.
+//
+// When the period specifies hours, minutes and seconds only, the result is precise.
+// however, when the period specifies years, months and days, it is impossible to be precise
+// because the result may depend on knowing date and timezone information, so the duration
+// is estimated on the basis of a year being 365.2425 days as per Gregorian calendar rules)
+// and a month being 1/12 of a that; days are all assumed to be 24 hours long.
+//
+// If the period is greater than approximately 290 years, the result will overflow.
+func (n Nano) Duration() (time.Duration, bool) {
+	// remember that these fields are fixed-point 1E1
+	ydE6 := n.years * (daysPerYearE4 * 100)
+	mdE6 := n.months * daysPerMonthE6
+	ddE6 := (n.weeks*7 + n.days) * oneE6
+	tdE6 := time.Duration((ydE6 + mdE6 + ddE6) * 8640)
+	return tdE6*time.Microsecond + n.hmsDuration(), tdE6 == 0
+}
+
+func (n Nano) hmsDuration() time.Duration {
+	// remember that hours and minutes are fixed-point 1E1
+	hh := time.Duration(n.hours) * (time.Hour / 10)
+	mm := time.Duration(n.minutes) * (time.Minute / 10)
+	return hh + mm + time.Duration(n.seconds)*time.Second + time.Duration(n.nanos)
+}
+
+// AddTo adds the period to a time, returning the result.
+// A flag is also returned that is true when the conversion was precise and false otherwise.
+//
+// When the period specifies hours, minutes and seconds only, the result is precise.
+// Also, when the period specifies whole years, months and days (i.e. without fractions), the
+// result is precise. However, when years, months or days contains fractions, the result
+// is only an approximation (it assumes that all days are 24 hours and every year is 365.2425
+// days, as per Gregorian calendar rules).
+func (n Nano) AddTo(t time.Time) (time.Time, bool) {
+	days := n.weeks*7 + n.days
+	if n.years%10 == 0 && n.months%10 == 0 && days%10 == 0 {
+		// in this case, time.AddDate provides an exact solution
+		t1 := t.AddDate(int(n.years/10), int(n.months/10), int(days/10))
+		return t1.Add(n.hmsDuration()), true
+	}
+
+	d, precise := n.Duration()
+	return t.Add(d), precise
+}
+
+// Normalise attempts to simplify the fields. It operates in either precise or imprecise mode,
+// as described for Period.Normalise, including the reconciliation of mixed signs.
+//
+// Unlike Period, the wide range means that hours and days are never moved to higher-order
+// fields in precise mode.
+func (n Nano) Normalise(precise bool) Nano {
+	a := n.harmonise(precise)
+
+	a.minutes += (a.seconds / 60) * 10
+	a.seconds = a.seconds % 60
+
+	a.hours += (a.minutes / 600) * 10
+	a.minutes = a.minutes % 600
+
+	if !precise {
+		a.days += a.weeks * 7
+		a.weeks = 0
+
+		a.days += (a.hours / 240) * 10
+		a.hours = a.hours % 240
+
+		dE6 := a.days * oneE5
+		a.months += (dE6 / daysPerMonthE6) * 10
+		a.days = (dE6 % daysPerMonthE6) / oneE5
+	}
+
+	a.years += (a.months / 120) * 10
+	a.months = a.months % 120
+
+	// use the Period algorithm to move fractions of the higher-order fields to the right
+	p64 := &period64{years: a.years, months: a.months, weeks: a.weeks, days: a.days, hours: a.hours, minutes: a.minutes, seconds: a.seconds * 10}
+	p64.moveFractionToRight()
+	return Nano{p64.years, p64.months, p64.weeks, p64.days, p64.hours, p64.minutes, p64.seconds / 10, a.nanos}
+}
+
+// harmonise resolves mixed signs in the same way as period64.harmonise.
+func (n Nano) harmonise(precise bool) Nano {
+	// remember that the fields other than seconds are fixed-point 1E1
+
+	if mixedSigns(n.hours, n.minutes, n.seconds, int64(n.nanos)) {
+		n.seconds, n.nanos = carryNanos(n.seconds+n.hours*360+n.minutes*6, int64(n.nanos))
+		n.hours, n.minutes = 0, 0
+	}
+
+	if mixedSigns(n.years, n.months) {
+		n.months += n.years * 12
+		n.years = 0
+	}
+
+	if mixedSigns(n.weeks, n.days) {
+		n.days += n.weeks * 7
+		n.weeks = 0
+	}
+
+	if !precise && mixedSigns(n.years, n.months, n.weeks, n.days, n.hours, n.minutes, n.seconds, int64(n.nanos)) {
+		// 2629746 seconds per month is 30.436875 days
+		ymdE1 := (n.years*12+n.months)*2629746 + (n.weeks*7+n.days)*86400
+		seconds := n.seconds + n.hours*360 + n.minutes*6 + ymdE1/10
+		n.seconds, n.nanos = carryNanos(seconds, int64(n.nanos)+(ymdE1%10)*oneE8)
+		n.years, n.months, n.weeks, n.days, n.hours, n.minutes = 0, 0, 0, 0, 0, 0
+	}
+
+	return n
+}
@@ -0,0 +1,306 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package period
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseNano(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value    string
+		reversed string
+		nano     Nano
+	}{
+		// note: the negative cases are also covered (see below)
+
+		{"P0", "P0D", Nano{}},
+		{"P0D", "P0D", Nano{}},
+		{"P1Y", "P1Y", Nano{years: 10}},
+		{"P1W", "P1W", Nano{weeks: 10}},
+		{"P1W3D", "P1W3D", Nano{weeks: 10, days: 30}},
+		{"PT1S", "PT1S", Nano{seconds: 1}},
+		{"PT0.1S", "PT0.1S", Nano{nanos: 100000000}},
+		{"PT0.000001S", "PT0.000001S", Nano{nanos: 1000}},
+		{"PT0,000000001S", "PT0.000000001S", Nano{nanos: 1}},
+		{"PT0.0000000019S", "PT0.000000001S", Nano{nanos: 1}},
+		{"PT1.5S", "PT1.5S", Nano{seconds: 1, nanos: 500000000}},
+		{"PT2M3.25S", "PT2M3.25S", Nano{minutes: 20, seconds: 3, nanos: 250000000}},
+		{"P2.5Y", "P2.5Y", Nano{years: 25}},
+		{"P3Y6M39DT1H2M4.123456789S", "P3Y6M39DT1H2M4.123456789S", Nano{30, 60, 0, 390, 10, 20, 4, 123456789}},
+		// wide range
+		{"P100000000000Y", "P100000000000Y", Nano{years: 1000000000000}},
+		{"PT1000000000000S", "PT1000000000000S", Nano{seconds: 1000000000000}},
+		{"P3000000000D", "P3000000000D", Nano{days: 30000000000}},
+	}
+	for i, c := range cases {
+		n, err := ParseNano(c.value, false)
+		g.Expect(err).NotTo(HaveOccurred(), info(i, c.value))
+		g.Expect(n).To(Equal(c.nano), info(i, c.value))
+		g.Expect(n.String()).To(Equal(c.reversed), info(i, c.value))
+
+		if !c.nano.IsZero() {
+			n, err = ParseNano("-"+c.value, false)
+			g.Expect(err).NotTo(HaveOccurred(), info(i, c.value))
+			g.Expect(n).To(Equal(c.nano.Negate()), info(i, c.value))
+			g.Expect(n.String()).To(Equal("-"+c.reversed), info(i, c.value))
+		}
+	}
+}
+
+func TestParseNanoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value    string
+		expected string
+	}{
+		{"", "cannot parse a blank string as a period"},
+		{"XY", "XY: expected 'P' period mark at the start"},
+		{"PTxS", "PTxS: expected a number but found 'x'"},
+		{"P1D2D", "P1D2D: 'D' designator cannot occur more than once"},
+		{"P0.1YT0.1S", "P0.1YT0.1S: 'Y' & 'S' only the last field can have a fraction"},
+		{"P", "P: expected 'Y', 'M', 'W', 'D', 'H', 'M', or 'S' designator"},
+	}
+	for i, c := range cases {
+		_, err := ParseNano(c.value)
+		g.Expect(err).To(HaveOccurred(), info(i, c.value))
+		g.Expect(err.Error()).To(Equal(c.expected), info(i, c.value))
+	}
+}
+
+func TestParseNanoWithNormalise(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value    string
+		expected string
+	}{
+		{"PT3600.5S", "PT1H0.5S"},
+		{"PT61.000000001S", "PT1M1.000000001S"},
+		{"P24M", "P2Y"},
+		{"PT1.5H", "PT1.5H"},
+		{"PT1.5H3S", "PT1H30M3S"},
+		{"P48D", "P48D"},
+		{"PT48H", "PT48H"},
+	}
+	for i, c := range cases {
+		n, err := ParseNano(c.value)
+		g.Expect(err).NotTo(HaveOccurred(), info(i, c.value))
+		g.Expect(n.String()).To(Equal(c.expected), info(i, c.value))
+
+		n, err = ParseNano("-" + c.value)
+		g.Expect(err).NotTo(HaveOccurred(), info(i, c.value))
+		g.Expect(n.String()).To(Equal("-"+c.expected), info(i, c.value))
+	}
+}
+
+func TestNewNano(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(NewNano(1, 2, 3, 4, 5, 6, 7).String()).To(Equal("P1Y2M3DT4H5M6.000000007S"))
+	g.Expect(NewNano(0, 0, 0, 0, 0, 1, 1500000000).String()).To(Equal("PT2.5S"))
+	g.Expect(NewNano(-1, 0, 0, 0, 0, 0, -1).String()).To(Equal("-P1YT0.000000001S"))
+	g.Expect(NewNano(0, 1, -1, 0, 0, 0, 0).String()).To(Equal("P1M-1D"))
+	g.Expect(NewNano(0, 0, 0, 0, 0, 1, -1).String()).To(Equal("PT0.999999999S"))
+}
+
+func TestNanoOf(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		source   time.Duration
+		expected string
+	}{
+		{0, "P0D"},
+		{time.Nanosecond, "PT0.000000001S"},
+		{1500 * time.Microsecond, "PT0.0015S"},
+		{3*time.Hour + 4*time.Minute + 5*time.Second + 6*time.Millisecond, "PT3H4M5.006S"},
+		{1000 * time.Hour, "PT1000H"},
+		{-90 * time.Second, "-PT1M30S"},
+	}
+	for i, c := range cases {
+		n := NanoOf(c.source)
+		g.Expect(n.String()).To(Equal(c.expected), info(i, c.expected))
+
+		d, precise := n.Duration()
+		g.Expect(d).To(Equal(c.source), info(i, c.expected))
+		g.Expect(precise).To(BeTrue(), info(i, c.expected))
+	}
+}
+
+func TestNanoToDuration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value    string
+		duration time.Duration
+		precise  bool
+	}{
+		{"P0D", time.Duration(0), true},
+		{"PT1.000000001S", time.Second + time.Nanosecond, true},
+		{"PT1H2M3.5S", time.Hour + 2*time.Minute + 3500*time.Millisecond, true},
+		{"P1D", 24 * time.Hour, false},
+		{"P1Y", oneYearApprox, false},
+		{"P1M", oneMonthApprox, false},
+	}
+	for i, c := range cases {
+		d, precise := MustParseNano(c.value, false).Duration()
+		g.Expect(d).To(Equal(c.duration), info(i, c.value))
+		g.Expect(precise).To(Equal(c.precise), info(i, c.value))
+	}
+}
+
+func TestNanoAddTo(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	t0 := utc(2020, 1, 31, 10, 0, 0, 0)
+
+	t1, precise := MustParseNano("P1DT1.000000001S").AddTo(t0)
+	g.Expect(t1).To(Equal(utc(2020, 2, 1, 10, 0, 1, 0).Add(time.Nanosecond)))
+	g.Expect(precise).To(BeTrue())
+
+	t2, precise := MustParseNano("-PT0.5S").AddTo(t0)
+	g.Expect(t2).To(Equal(utc(2020, 1, 31, 9, 59, 59, 500)))
+	g.Expect(precise).To(BeTrue())
+}
+
+func TestNanoAdd(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		one, two, expected string
+	}{
+		{"PT0.6S", "PT0.7S", "PT1.3S"},
+		{"P1Y", "PT0.000000001S", "P1YT0.000000001S"},
+		{"PT1.5S", "-PT0.75S", "PT0.75S"},
+		{"-PT0.6S", "-PT0.7S", "-PT1.3S"},
+	}
+	for i, c := range cases {
+		n := MustParseNano(c.one).Add(MustParseNano(c.two))
+		g.Expect(n.String()).To(Equal(c.expected), info(i, c.expected))
+	}
+}
+
+func TestNanoComponents(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	n := MustParseNano("P1.5Y", false).Add(MustParseNano("P2M3DT4H5M6.25S", false))
+	g.Expect(n.Years()).To(Equal(int64(1)))
+	g.Expect(n.YearsFloat()).To(Equal(1.5))
+	g.Expect(n.Months()).To(Equal(int64(2)))
+	g.Expect(n.Days()).To(Equal(int64(3)))
+	g.Expect(n.Hours()).To(Equal(int64(4)))
+	g.Expect(n.Minutes()).To(Equal(int64(5)))
+	g.Expect(n.Seconds()).To(Equal(int64(6)))
+	g.Expect(n.SecondsFloat()).To(Equal(6.25))
+	g.Expect(n.Nanoseconds()).To(Equal(int64(250000000)))
+	g.Expect(n.Sign()).To(Equal(1))
+	g.Expect(n.Negate().Sign()).To(Equal(-1))
+	g.Expect(n.Negate().Abs()).To(Equal(n))
+	g.Expect(n.OnlyYMD().String()).To(Equal("P1.5Y2M3D"))
+	g.Expect(n.OnlyHMS().String()).To(Equal("PT4H5M6.25S"))
+}
+
+func TestNanoPeriodConversion(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []string{
+		"P0D", "P1Y2M3W4DT5H6M7.8S", "P2.5Y", "PT0.1S", "P214748364D", "PT214748364.7S",
+	}
+	for i, c := range cases {
+		p := MustParse(c, false)
+		n := p.Nano()
+		g.Expect(n.String()).To(Equal(p.String()), info(i, c))
+
+		back, err := n.Period()
+		g.Expect(err).NotTo(HaveOccurred(), info(i, c))
+		g.Expect(back).To(Equal(p), info(i, c))
+
+		back, err = n.Negate().Period()
+		g.Expect(err).NotTo(HaveOccurred(), info(i, c))
+		g.Expect(back).To(Equal(p.Negate()), info(i, c))
+	}
+
+	bad := []struct {
+		value    string
+		expected string
+	}{
+		{"PT0.01S", "PT0.01S: precision would be lost in seconds"},
+		{"P2147483648Y", "P2147483648Y: integer overflow occurred in years"},
+		{"PT214748365S", "PT214748365S: integer overflow occurred in seconds"},
+		{"-P214748365D", "-P214748365D: integer overflow occurred in days"},
+	}
+	for i, c := range bad {
+		_, err := MustParseNano(c.value, false).Period()
+		g.Expect(err).To(HaveOccurred(), info(i, c.value))
+		g.Expect(err.Error()).To(Equal(c.expected), info(i, c.value))
+	}
+}
+
+func TestNanoScanAndMarshal(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []string{"P0D", "P1Y3M", "P48M", "PT1.000000001S", "-P100000000000Y"}
+	for i, c := range cases {
+		var n Nano
+		g.Expect(n.Scan(c)).NotTo(HaveOccurred(), info(i, c))
+		g.Expect(n.String()).To(Equal(c), info(i, c))
+
+		v, err := n.Value()
+		g.Expect(err).NotTo(HaveOccurred(), info(i, c))
+		g.Expect(v).To(Equal(c), info(i, c))
+
+		var m Nano
+		g.Expect(m.Scan([]byte(c))).NotTo(HaveOccurred(), info(i, c))
+		g.Expect(m).To(Equal(n), info(i, c))
+
+		bb, err := json.Marshal(n)
+		g.Expect(err).NotTo(HaveOccurred(), info(i, c))
+		g.Expect(string(bb)).To(Equal(`"`+c+`"`), info(i, c))
+
+		var j Nano
+		g.Expect(json.Unmarshal(bb, &j)).NotTo(HaveOccurred(), info(i, c))
+		g.Expect(j).To(Equal(n), info(i, c))
+
+		bb, err = n.MarshalBinary()
+		g.Expect(err).NotTo(HaveOccurred(), info(i, c))
+
+		var b Nano
+		g.Expect(b.UnmarshalBinary(bb)).NotTo(HaveOccurred(), info(i, c))
+		g.Expect(b).To(Equal(n), info(i, c))
+	}
+
+	var n Nano
+	g.Expect(n.Scan(1)).To(MatchError("int 1 is not a meaningful period"))
+	g.Expect(n.Scan(nil)).NotTo(HaveOccurred())
+}
+
+func TestNanoWeeks(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	n := MustParseNano("P1W3DT0.5S")
+	g.Expect(n.String()).To(Equal("P1W3DT0.5S"))
+	g.Expect(n.ExplicitWeeks()).To(Equal(int64(1)))
+	g.Expect(n.ExplicitDays()).To(Equal(int64(3)))
+	g.Expect(n.Days()).To(Equal(int64(10)))
+	g.Expect(n.Normalise(false).String()).To(Equal("P10DT0.5S"))
+
+	mixed := MustParseNano("P1Y-1D")
+	g.Expect(mixed.Normalise(true).String()).To(Equal("P1Y-1D"))
+	g.Expect(mixed.Normalise(false).IsMixed()).To(BeFalse())
+
+	p := MustParse("P2W")
+	g.Expect(p.Nano().String()).To(Equal("P2W"))
+	back, err := p.Nano().Period()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(back).To(Equal(p))
+}
@@ -24,7 +24,10 @@ func MustParse(value string, normalise ...bool) Period {
 
 // Parse parses strings that specify periods using ISO-8601 rules.
 //
-// In addition, a plus or minus sign can precede the period, e.g. "-P10D"
+// In addition, a plus or minus sign can precede the period, e.g. "-P10D". Each field
+// can also have its own sign, giving a mixed-sign period such as "P1M-1D" (one month
+// minus one day). A sign before the period applies to all the fields, so "-P1M-1D"
+// is the same as "P-1M1D".
 //
 // By default, the value is normalised, e.g. multiple of 12 months become years
 // so "P24M" is the same as "P2Y". However, this is done without loss of precision,
@@ -76,7 +79,7 @@ func parse(period string, normalise bool) (*period64, error) {
 	}
 	remaining = remaining[1:]
 
-	var number, weekValue, prevFraction int64
+	var number, prevFraction int64
 	result := &period64{input: period, neg: neg}
 	var years, months, weeks, days, hours, minutes, seconds itemState
 	var designator, prevDesignator byte
@@ -113,7 +116,7 @@ func parse(period string, normalise bool) (*period64, error) {
 			case 'Y':
 				years, err = years.testAndSet(number, 'Y', result, &result.years)
 			case 'W':
-				weeks, err = weeks.testAndSet(number, 'W', result, &weekValue)
+				weeks, err = weeks.testAndSet(number, 'W', result, &result.weeks)
 			case 'D':
 				days, err = days.testAndSet(number, 'D', result, &result.days)
 			case 'H':
@@ -144,8 +147,6 @@ func parse(period string, normalise bool) (*period64, error) {
 		return nil, fmt.Errorf("%s: expected 'Y', 'M', 'W', 'D', 'H', 'M', or 'S' designator", period)
 	}
 
-	result.days += weekValue * 7
-
 	if normalise {
 		result = result.normalise64(true)
 	}
@@ -178,6 +179,15 @@ func (i itemState) testAndSet(number int64, designator byte, result *period64, v
 //-------------------------------------------------------------------------------------------------
 
 func parseNextField(str, original string) (int64, byte, string, error) {
+	// each field of a mixed-sign period can have its own sign
+	neg := false
+	if str[0] == '-' {
+		neg = true
+		str = str[1:]
+	} else if str[0] == '+' {
+		str = str[1:]
+	}
+
 	i := scanDigits(str)
 	if i < 0 {
 		return 0, 0, "", fmt.Errorf("%s: missing designator at the end", original)
@@ -185,6 +195,9 @@ func parseNextField(str, original string) (int64, byte, string, error) {
 
 	des := str[i]
 	number, err := parseDecimalNumber(str[:i], original, des)
+	if neg {
+		number = -number
+	}
 	return number, des, str[i+1:], err
 }
 
@@ -235,3 +248,91 @@ func scanDigits(s string) int {
 func isDigit(c rune) bool {
 	return ('0' <= c && c <= '9') || c == '.' || c == ','
 }
+
+//-------------------------------------------------------------------------------------------------
+
+// MustParseNano is as per ParseNano except that it panics if the string cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+// By default, the value is normalised.
+// Normalisation can be disabled using the optional flag.
+func MustParseNano(value string, normalise ...bool) Nano {
+	n, err := ParseNano(value, normalise...)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// ParseNano parses strings that specify periods using ISO-8601 rules, as per Parse.
+// The seconds may have up to nine decimal places, e.g. "PT0.000000001S"; any further
+// places are ignored. The other fields can have at most one decimal place.
+//
+// By default, the value is normalised (see Nano.Normalise in precise mode).
+// Normalisation can be disabled using the optional flag.
+func ParseNano(period string, normalise ...bool) (Nano, error) {
+	if period == "" || period == "-" || period == "+" {
+		return Nano{}, fmt.Errorf("cannot parse a blank string as a period")
+	}
+
+	if period == "P0" {
+		return Nano{}, nil
+	}
+
+	// this validates the syntax, but truncates the seconds to one decimal place
+	p64, err := parse(period, false)
+	if err != nil {
+		return Nano{}, err
+	}
+
+	n := Nano{
+		years: p64.years, months: p64.months, weeks: p64.weeks, days: p64.days,
+		hours: p64.hours, minutes: p64.minutes, seconds: p64.seconds / 10,
+	}
+
+	if frac, neg := secondsFraction(period); frac != "" {
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		nanos, err := strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 32)
+		if err != nil {
+			return Nano{}, fmt.Errorf("%s: expected a number but found 'S'", period)
+		}
+		if neg {
+			nanos = -nanos
+		}
+		n.nanos = int32(nanos)
+	}
+
+	if p64.neg {
+		n = n.Negate()
+	}
+
+	if len(normalise) == 0 || normalise[0] {
+		n = n.Normalise(true)
+	}
+
+	return n, nil
+}
+
+// secondsFraction gets the decimal digits after the point in the seconds field, if any,
+// and whether the seconds field has its own minus sign.
+func secondsFraction(period string) (string, bool) {
+	if !strings.HasSuffix(period, "S") {
+		return "", false
+	}
+
+	end := len(period) - 1
+	point := -1
+	for i := end - 1; i >= 0; i-- {
+		switch c := period[i]; {
+		case c == '.' || c == ',':
+			point = i
+		case c < '0' || c > '9':
+			if point < 0 {
+				return "", false
+			}
+			return period[point+1 : end], c == '-'
+		}
+	}
+	return "", false
+}
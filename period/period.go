@@ -5,7 +5,6 @@
 package period
 
 import (
-	"fmt"
 	"time"
 )
 
@@ -23,7 +22,7 @@ const hundredMs = 100 * time.Millisecond
 // reminder: int64 overflow is after 9,223,372,036,854,775,807 (math.MaxInt64)
 
 // Period holds a period of time and provides conversion to/from ISO-8601 representations.
-// Therefore there are six fields: years, months, days, hours, minutes, and seconds.
+// Therefore there are seven fields: years, months, weeks, days, hours, minutes, and seconds.
 //
 // In the ISO representation, decimal fractions are supported, although only the last non-zero
 // component is allowed to have a fraction according to the Standard. For example "P2.5Y"
@@ -36,22 +35,25 @@ const hundredMs = 100 * time.Millisecond
 // The implementation limits the range of possible values to ± 2^16 / 10 in each field.
 // Note in particular that the range of years is limited to approximately ± 3276.
 //
-// The concept of weeks exists in string representations of periods, but otherwise weeks
-// are unimportant. The period contains a number of days from which the number of weeks can
-// be calculated when needed.
+// Weeks are held separately from days, so that the designators of a parsed period are
+// preserved: "P2W", "P14D" and "P1W7D" are all the same length but each is formatted as it
+// was parsed. Weeks are always seven days long, so the accessors such as Days and Weeks
+// combine the two fields; ExplicitWeeks and ExplicitDays give the separate fields. Use
+// PreferWeeks or PreferDays to choose how the days and weeks are expressed.
 //
-// Note that although fractional weeks can be parsed, they will never be returned via String().
-// This is because the number of weeks is always inferred from the number of days.
+// Usually, all the fields have the same sign. However, mixed-sign periods are also allowed, such
+// as "P1M-1D" (one month minus one day). In the ISO representation, each field of a mixed-sign
+// period carries its own sign. See IsMixed.
 //
 type Period struct {
-	years, months, days, hours, minutes, seconds int32
+	years, months, weeks, days, hours, minutes, seconds int32
 }
 
 // NewYMD creates a simple period without any fractional parts. The fields are initialised verbatim
 // without any normalisation; e.g. 12 months will not become 1 year. Use the Normalise method if you
 // need to.
 //
-// All the parameters must have the same sign (otherwise a panic occurs).
+// The parameters may have different signs, giving a mixed-sign period.
 // Because this implementation uses int32 internally, the paramters must
 // be within the range ± 2^32 / 10.
 func NewYMD(years, months, days int) Period {
@@ -62,7 +64,7 @@ func NewYMD(years, months, days int) Period {
 // without any normalisation; e.g. 120 seconds will not become 2 minutes. Use the Normalise method
 // if you need to.
 //
-// All the parameters must have the same sign (otherwise a panic occurs).
+// The parameters may have different signs, giving a mixed-sign period.
 // Because this implementation uses int32 internally, the paramters must
 // be within the range ± 2^32 / 10.
 func NewHMS(hours, minutes, seconds int) Period {
@@ -73,17 +75,22 @@ func NewHMS(hours, minutes, seconds int) Period {
 // without any normalisation; e.g. 120 seconds will not become 2 minutes. Use the Normalise method
 // if you need to.
 //
-// All the parameters must have the same sign (otherwise a panic occurs).
+// The parameters may have different signs, giving a mixed-sign period such as New(0, 1, -1, 0, 0, 0),
+// which is one month minus one day.
 func New(years, months, days, hours, minutes, seconds int) Period {
-	if (years >= 0 && months >= 0 && days >= 0 && hours >= 0 && minutes >= 0 && seconds >= 0) ||
-		(years <= 0 && months <= 0 && days <= 0 && hours <= 0 && minutes <= 0 && seconds <= 0) {
-		return Period{
-			int32(years) * 10, int32(months) * 10, int32(days) * 10,
-			int32(hours) * 10, int32(minutes) * 10, int32(seconds) * 10,
-		}
+	return Period{
+		int32(years) * 10, int32(months) * 10, 0, int32(days) * 10,
+		int32(hours) * 10, int32(minutes) * 10, int32(seconds) * 10,
 	}
-	panic(fmt.Sprintf("Periods must have homogeneous signs; got P%dY%dM%dDT%dH%dM%dS",
-		years, months, days, hours, minutes, seconds))
+}
+
+// NewYMWD creates a simple period without any fractional parts, having years, months, weeks
+// and days. The weeks are kept separately from the days, as they would be by parsing "P1W3D",
+// for example. The fields are initialised verbatim without any normalisation.
+//
+// The parameters may have different signs, giving a mixed-sign period.
+func NewYMWD(years, months, weeks, days int) Period {
+	return Period{int32(years) * 10, int32(months) * 10, int32(weeks) * 10, int32(days) * 10, 0, 0, 0}
 }
 
 // NewOf converts a time duration to a Period, and also indicates whether the conversion is precise.
@@ -112,7 +119,7 @@ func NewOf(duration time.Duration) (p Period, precise bool) {
 		// simple HMS case
 		minutes := d % time.Hour / time.Minute
 		seconds := d % time.Minute / hundredMs
-		return Period{0, 0, 0, sign10 * int32(totalHours), sign10 * int32(minutes), sign * int32(seconds)}, true
+		return Period{0, 0, 0, 0, sign10 * int32(totalHours), sign10 * int32(minutes), sign * int32(seconds)}, true
 	}
 
 	totalDays := totalHours / 24 // ignoring daylight savings adjustments
@@ -121,7 +128,7 @@ func NewOf(duration time.Duration) (p Period, precise bool) {
 		hours := totalHours - totalDays*24
 		minutes := d % time.Hour / time.Minute
 		seconds := d % time.Minute / hundredMs
-		return Period{0, 0, sign10 * int32(totalDays), sign10 * int32(hours), sign10 * int32(minutes), sign * int32(seconds)}, false
+		return Period{0, 0, 0, sign10 * int32(totalDays), sign10 * int32(hours), sign10 * int32(minutes), sign * int32(seconds)}, false
 	}
 
 	// TODO it is uncertain whether this is too imprecise and should be improved
@@ -129,7 +136,7 @@ func NewOf(duration time.Duration) (p Period, precise bool) {
 	months := ((oneE4 * totalDays) / daysPerMonthE4) - (12 * years)
 	hours := totalHours - totalDays*24
 	totalDays = ((totalDays * oneE4) - (daysPerMonthE4 * months) - (daysPerYearE4 * years)) / oneE4
-	return Period{sign10 * int32(years), sign10 * int32(months), sign10 * int32(totalDays), sign10 * int32(hours), 0, 0}, false
+	return Period{sign10 * int32(years), sign10 * int32(months), 0, sign10 * int32(totalDays), sign10 * int32(hours), 0, 0}, false
 }
 
 // Between converts the span between two times to a period. Based on the Gregorian conversion
@@ -211,41 +218,66 @@ func (period Period) IsZero() bool {
 	return period == Period{}
 }
 
-// IsPositive returns true if any field is greater than zero. By design, this also implies that
-// all the other fields are greater than or equal to zero.
+// IsPositive returns true if the period is greater than zero. When all the fields have the
+// same sign, this is true if any field is greater than zero. For mixed-sign periods, see Sign.
 func (period Period) IsPositive() bool {
-	return period.years > 0 || period.months > 0 || period.days > 0 ||
-		period.hours > 0 || period.minutes > 0 || period.seconds > 0
+	return period.Sign() > 0
 }
 
-// IsNegative returns true if any field is negative. By design, this also implies that
-// all the other fields are negative or zero.
+// IsNegative returns true if the period is less than zero. When all the fields have the
+// same sign, this is true if any field is negative. For mixed-sign periods, see Sign.
 func (period Period) IsNegative() bool {
-	return period.years < 0 || period.months < 0 || period.days < 0 ||
+	return period.Sign() < 0
+}
+
+// IsMixed returns true if the period has both positive and negative fields, such as "P1M-1D".
+func (period Period) IsMixed() bool {
+	pos, neg := period.signs()
+	return pos && neg
+}
+
+func (period Period) signs() (pos, neg bool) {
+	pos = period.years > 0 || period.months > 0 || period.weeks > 0 || period.days > 0 ||
+		period.hours > 0 || period.minutes > 0 || period.seconds > 0
+	neg = period.years < 0 || period.months < 0 || period.weeks < 0 || period.days < 0 ||
 		period.hours < 0 || period.minutes < 0 || period.seconds < 0
+	return pos, neg
 }
 
 // Sign returns +1 for positive periods and -1 for negative periods. If the period is zero, it returns zero.
+//
+// For mixed-sign periods, the result is the sign of the approximate duration (see DurationApprox),
+// so "P1M-1D" is positive. This is zero when the fields cancel out, e.g. "PT1H-60M".
 func (period Period) Sign() int {
-	if period.IsZero() {
+	pos, neg := period.signs()
+	switch {
+	case pos && neg:
+		// float64 avoids overflow for the widest periods
+		days := float64(totalDaysApproxE7(period))/oneE7 + float64(totalSecondsE3(period))/(86400*1000)
+		if days > 0 {
+			return 1
+		} else if days < 0 {
+			return -1
+		}
 		return 0
-	}
-	if period.IsNegative() {
+	case neg:
 		return -1
+	case pos:
+		return 1
 	}
-	return 1
+	return 0
 }
 
-// OnlyYMD returns a new Period with only the year, month and day fields. The hour,
+// OnlyYMD returns a new Period with only the year, month, week and day fields. The hour,
 // minute and second fields are zeroed.
 func (period Period) OnlyYMD() Period {
-	return Period{period.years, period.months, period.days, 0, 0, 0}
+	return Period{period.years, period.months, period.weeks, period.days, 0, 0, 0}
 }
 
 // OnlyHMS returns a new Period with only the hour, minute and second fields. The year,
 // month and day fields are zeroed.
 func (period Period) OnlyHMS() Period {
-	return Period{0, 0, 0, period.hours, period.minutes, period.seconds}
+	return Period{0, 0, 0, 0, period.hours, period.minutes, period.seconds}
 }
 
 // Abs converts a negative period to a positive one.
@@ -270,7 +302,7 @@ func (period Period) condNegate(neg bool) Period {
 
 // Negate changes the sign of the period.
 func (period Period) Negate() Period {
-	return Period{-period.years, -period.months, -period.days, -period.hours, -period.minutes, -period.seconds}
+	return Period{-period.years, -period.months, -period.weeks, -period.days, -period.hours, -period.minutes, -period.seconds}
 }
 
 func absInt32(v int32) int32 {
@@ -310,45 +342,92 @@ func (period Period) MonthsFloat() float32 {
 	return float32(period.months) / 10
 }
 
-// Days gets the whole number of days in the period. This includes the implied
+// Days gets the whole number of days in the period. This includes the
 // number of weeks but does not include any other field.
 func (period Period) Days() int {
-	return int(period.days / 10)
+	return int(period.totalDays() / 10)
 }
 
-// DaysFloat gets the number of days in the period. This includes the implied
+// DaysFloat gets the number of days in the period. This includes the
 // number of weeks but does not include any other field.
 func (period Period) DaysFloat() float32 {
-	return float32(period.days) / 10
+	return float32(period.totalDays()) / 10
+}
+
+// totalDays gets the days including the weeks, fixed-point 1E1
+func (period Period) totalDays() int32 {
+	return period.weeks*7 + period.days
 }
 
-// Weeks calculates the number of whole weeks from the number of days. If the result
-// would contain a fraction, it is truncated.
+// Weeks calculates the number of whole weeks from the number of days, including
+// the weeks field. If the result would contain a fraction, it is truncated.
 // The result is the number of weeks and does not include any other field.
 //
-// Note that weeks are synthetic: they are internally represented using days.
 // See ModuloDays(), which returns the number of days excluding whole weeks.
+// See also ExplicitWeeks.
 func (period Period) Weeks() int {
-	return int(period.days) / 70
+	return int(period.totalDays()) / 70
 }
 
-// WeeksFloat calculates the number of weeks from the number of days.
-// The result is the number of weeks and does not include any other field.
+// WeeksFloat calculates the number of weeks from the number of days, including
+// the weeks field. The result is the number of weeks and does not include any other field.
 func (period Period) WeeksFloat() float32 {
-	return float32(period.days) / 70
+	return float32(period.totalDays()) / 70
 }
 
 // ModuloDays calculates the whole number of days remaining after the whole number of weeks
 // has been excluded.
 func (period Period) ModuloDays() int {
-	days := absInt32(period.days) % 70
+	days := absInt32(period.totalDays()) % 70
 	f := int(days / 10)
-	if period.days < 0 {
+	if period.totalDays() < 0 {
 		return -f
 	}
 	return f
 }
 
+// ExplicitWeeks gets the whole number of weeks held in the weeks field, e.g. 1 for "P1W3D".
+// Unlike Weeks, this does not include the days field.
+func (period Period) ExplicitWeeks() int {
+	return int(period.weeks / 10)
+}
+
+// ExplicitWeeksFloat gets the number of weeks held in the weeks field, including any fraction.
+// Unlike WeeksFloat, this does not include the days field.
+func (period Period) ExplicitWeeksFloat() float32 {
+	return float32(period.weeks) / 10
+}
+
+// ExplicitDays gets the whole number of days held in the days field, e.g. 3 for "P1W3D".
+// Unlike Days, this does not include the weeks field.
+func (period Period) ExplicitDays() int {
+	return int(period.days / 10)
+}
+
+// ExplicitDaysFloat gets the number of days held in the days field, including any fraction.
+// Unlike DaysFloat, this does not include the weeks field.
+func (period Period) ExplicitDaysFloat() float32 {
+	return float32(period.days) / 10
+}
+
+// PreferWeeks returns an equivalent period in which the weeks and days are expressed
+// as whole weeks plus the remaining days, so "P10D" becomes "P1W3D" and "P14D"
+// becomes "P2W".
+func (period Period) PreferWeeks() Period {
+	td := period.totalDays()
+	period.weeks = (td / 70) * 10
+	period.days = td % 70
+	return period
+}
+
+// PreferDays returns an equivalent period in which any weeks are converted to days,
+// so "P1W3D" becomes "P10D" and "P2W" becomes "P14D".
+func (period Period) PreferDays() Period {
+	period.days = period.totalDays()
+	period.weeks = 0
+	return period
+}
+
 // Hours gets the whole number of hours in the period.
 // The result is the number of hours and does not include any other field.
 func (period Period) Hours() int {
@@ -438,7 +517,7 @@ func totalDaysApproxE7(period Period) int64 {
 	// remember that the fields are all fixed-point 1E1
 	ydE6 := int64(period.years) * (daysPerYearE4 * 100)
 	mdE6 := int64(period.months) * daysPerMonthE6
-	ddE6 := int64(period.days) * oneE6
+	ddE6 := int64(period.totalDays()) * oneE6
 	return ydE6 + mdE6 + ddE6
 }
 
@@ -474,11 +553,18 @@ func (period Period) TotalMonthsApprox() int {
 // Multiples of 60 seconds become minutes.
 // Multiples of 60 minutes become hours.
 // Multiples of 12 months become years.
+// Weeks are left as they are, unless they contain a fraction that can be moved to the days.
 //
 // Additionally, in imprecise mode:
+// Weeks become days.
 // Multiples of 24 hours become days.
 // Multiples of approx. 30.4 days become months.
 //
+// For mixed-sign periods, the signs are also reconciled where the fields can be combined.
+// In precise mode, this applies to hours with minutes and seconds, and to years with
+// months, and to weeks with days; e.g. "PT1H-10M" becomes "PT50M". In imprecise mode, all the fields can be
+// combined; e.g. "P1M-1D" becomes "P29DT10H29M6S" (using 30.436875 days per month).
+//
 // Note that leap seconds are disregarded: every minute is assumed to have 60 seconds.
 func (period Period) Normalise(precise bool) Period {
 	n, _ := period.toPeriod64("").normalise64(precise).toPeriod()
@@ -545,7 +631,7 @@ func (period Period) doSimplify(precise bool, a, b, c, d int32) Period {
 	// single year is dropped if there are some months
 	if ap.years == 10 &&
 		0 < ap.months && ap.months <= a &&
-		ap.days == 0 {
+		ap.totalDays() == 0 {
 		ap.months += 120
 		ap.years = 0
 	}
@@ -559,6 +645,10 @@ func (period Period) doSimplify(precise bool, a, b, c, d int32) Period {
 		return ap.condNegate(neg)
 	}
 
+	if ap.weeks%10 != 0 {
+		return ap.condNegate(neg)
+	}
+
 	if ap.days%10 != 0 {
 		// day fraction is dropped for periods of at least a year (1:365)
 		days := ap.days / 10
@@ -569,6 +659,7 @@ func (period Period) doSimplify(precise bool, a, b, c, d int32) Period {
 	}
 
 	if !precise && ap.days == 10 &&
+		ap.weeks == 0 &&
 		ap.years == 0 &&
 		ap.months == 0 &&
 		0 < ap.hours && ap.hours <= b {
@@ -579,7 +670,7 @@ func (period Period) doSimplify(precise bool, a, b, c, d int32) Period {
 	if ap.hours%10 != 0 {
 		// hour fraction is dropped for periods of at least a month (1:720)
 		hours := ap.hours / 10
-		if !precise && (ap.years > 0 || ap.months > 0 || ap.days >= 300) && hours == 0 {
+		if !precise && (ap.years > 0 || ap.months > 0 || ap.totalDays() >= 300) && hours == 0 {
 			ap.hours = 0
 		}
 		return ap.condNegate(neg)
@@ -594,7 +685,7 @@ func (period Period) doSimplify(precise bool, a, b, c, d int32) Period {
 	if ap.minutes%10 != 0 {
 		// minute fraction is dropped for periods of at least a day (1:1440)
 		minutes := ap.minutes / 10
-		if !precise && (ap.years > 0 || ap.months > 0 || ap.days > 0 || ap.hours >= 240) && minutes == 0 {
+		if !precise && (ap.years > 0 || ap.months > 0 || ap.totalDays() > 0 || ap.hours >= 240) && minutes == 0 {
 			ap.minutes = 0
 		}
 		return ap.condNegate(neg)
@@ -610,7 +701,7 @@ func (period Period) doSimplify(precise bool, a, b, c, d int32) Period {
 	if ap.seconds%10 != 0 {
 		// second fraction is dropped for periods of at least an hour (1:3600)
 		seconds := ap.seconds / 10
-		if !precise && (ap.years > 0 || ap.months > 0 || ap.days > 0 || ap.hours > 0 || ap.minutes >= 600) && seconds == 0 {
+		if !precise && (ap.years > 0 || ap.months > 0 || ap.totalDays() > 0 || ap.hours > 0 || ap.minutes >= 600) && seconds == 0 {
 			ap.seconds = 0
 		}
 	}
@@ -8,24 +8,26 @@ import (
 
 // used for stages in arithmetic
 type period64 struct {
-	// always positive values
-	years, months, days, hours, minutes, seconds int64
+	// always positive values, except for mixed-sign periods
+	years, months, weeks, days, hours, minutes, seconds int64
 	// true if the period is negative
 	neg   bool
 	input string
 }
 
 func (period Period) toPeriod64(input string) *period64 {
-	if period.IsNegative() {
+	if pos, neg := period.signs(); neg && !pos {
 		return &period64{
-			years: int64(-period.years), months: int64(-period.months), days: int64(-period.days),
+			years: int64(-period.years), months: int64(-period.months),
+			weeks: int64(-period.weeks), days: int64(-period.days),
 			hours: int64(-period.hours), minutes: int64(-period.minutes), seconds: int64(-period.seconds),
 			neg:   true,
 			input: input,
 		}
 	}
 	return &period64{
-		years: int64(period.years), months: int64(period.months), days: int64(period.days),
+		years: int64(period.years), months: int64(period.months),
+		weeks: int64(period.weeks), days: int64(period.days),
 		hours: int64(period.hours), minutes: int64(period.minutes), seconds: int64(period.seconds),
 		input: input,
 	}
@@ -33,22 +35,25 @@ func (period Period) toPeriod64(input string) *period64 {
 
 func (p64 *period64) toPeriod() (Period, error) {
 	var f []string
-	if p64.years > math.MaxInt32 {
+	if overflows32(p64.years) {
 		f = append(f, "years")
 	}
-	if p64.months > math.MaxInt32 {
+	if overflows32(p64.months) {
 		f = append(f, "months")
 	}
-	if p64.days > math.MaxInt32 {
+	if overflows32(p64.weeks) {
+		f = append(f, "weeks")
+	}
+	if overflows32(p64.days) {
 		f = append(f, "days")
 	}
-	if p64.hours > math.MaxInt32 {
+	if overflows32(p64.hours) {
 		f = append(f, "hours")
 	}
-	if p64.minutes > math.MaxInt32 {
+	if overflows32(p64.minutes) {
 		f = append(f, "minutes")
 	}
-	if p64.seconds > math.MaxInt32 {
+	if overflows32(p64.seconds) {
 		f = append(f, "seconds")
 	}
 
@@ -61,19 +66,65 @@ func (p64 *period64) toPeriod() (Period, error) {
 
 	if p64.neg {
 		return Period{
-			int32(-p64.years), int32(-p64.months), int32(-p64.days),
+			int32(-p64.years), int32(-p64.months), int32(-p64.weeks), int32(-p64.days),
 			int32(-p64.hours), int32(-p64.minutes), int32(-p64.seconds),
 		}, nil
 	}
 
 	return Period{
-		int32(p64.years), int32(p64.months), int32(p64.days),
+		int32(p64.years), int32(p64.months), int32(p64.weeks), int32(p64.days),
 		int32(p64.hours), int32(p64.minutes), int32(p64.seconds),
 	}, nil
 }
 
+func overflows32(v int64) bool {
+	return v > math.MaxInt32 || v < -math.MaxInt32
+}
+
 func (p64 *period64) normalise64(precise bool) *period64 {
-	return p64.rippleUp(precise).moveFractionToRight()
+	return p64.harmonise(precise).rippleUp(precise).moveFractionToRight()
+}
+
+// harmonise resolves mixed signs by folding fields into the lowest-order field of
+// their group; rippleUp then redistributes them with a consistent sign. In precise
+// mode, the groups are years with months, weeks with days, and hours with minutes
+// and seconds.
+// In imprecise mode, all the fields form one group.
+func (p64 *period64) harmonise(precise bool) *period64 {
+	// remember that the fields are all fixed-point 1E1
+
+	if mixedSigns(p64.hours, p64.minutes, p64.seconds) {
+		p64.seconds += p64.hours*3600 + p64.minutes*60
+		p64.hours, p64.minutes = 0, 0
+	}
+
+	if mixedSigns(p64.years, p64.months) {
+		p64.months += p64.years * 12
+		p64.years = 0
+	}
+
+	if mixedSigns(p64.weeks, p64.days) {
+		p64.days += p64.weeks * 7
+		p64.weeks = 0
+	}
+
+	if !precise && mixedSigns(p64.years, p64.months, p64.days, p64.weeks, p64.hours, p64.minutes, p64.seconds) {
+		// 2629746 seconds per month is 30.436875 days
+		p64.seconds += (p64.years*12+p64.months)*2629746 + (p64.weeks*7+p64.days)*86400 +
+			p64.hours*3600 + p64.minutes*60
+		p64.years, p64.months, p64.weeks, p64.days, p64.hours, p64.minutes = 0, 0, 0, 0, 0, 0
+	}
+
+	return p64
+}
+
+func mixedSigns(vv ...int64) bool {
+	pos, neg := false, false
+	for _, v := range vv {
+		pos = pos || v > 0
+		neg = neg || v < 0
+	}
+	return pos && neg
 }
 
 func (p64 *period64) rippleUp(precise bool) *period64 {
@@ -85,13 +136,18 @@ func (p64 *period64) rippleUp(precise bool) *period64 {
 	p64.hours += (p64.minutes / 600) * 10
 	p64.minutes = p64.minutes % 600
 
+	if !precise {
+		p64.days += p64.weeks * 7
+		p64.weeks = 0
+	}
+
 	// 32670-(32670/60)-(32670/3600) = 32760 - 546 - 9.1 = 32204.9
-	if !precise || p64.hours > 32204 {
+	if !precise || absInt64(p64.hours) > 32204 {
 		p64.days += (p64.hours / 240) * 10
 		p64.hours = p64.hours % 240
 	}
 
-	if !precise || p64.days > 32760 {
+	if !precise || absInt64(p64.days) > 32760 {
 		dE6 := p64.days * oneE5
 		p64.months += (dE6 / daysPerMonthE6) * 10
 		p64.days = (dE6 % daysPerMonthE6) / oneE5
@@ -109,17 +165,23 @@ func (p64 *period64) moveFractionToRight() *period64 {
 	// remember that the fields are all fixed-point 1E1
 
 	y10 := p64.years % 10
-	if y10 != 0 && (p64.months != 0 || p64.days != 0 || p64.hours != 0 || p64.minutes != 0 || p64.seconds != 0) {
+	if y10 != 0 && (p64.months != 0 || p64.weeks != 0 || p64.days != 0 || p64.hours != 0 || p64.minutes != 0 || p64.seconds != 0) {
 		p64.months += y10 * 12
 		p64.years = (p64.years / 10) * 10
 	}
 
 	m10 := p64.months % 10
-	if m10 != 0 && (p64.days != 0 || p64.hours != 0 || p64.minutes != 0 || p64.seconds != 0) {
+	if m10 != 0 && (p64.weeks != 0 || p64.days != 0 || p64.hours != 0 || p64.minutes != 0 || p64.seconds != 0) {
 		p64.days += (m10 * daysPerMonthE6) / oneE6
 		p64.months = (p64.months / 10) * 10
 	}
 
+	w10 := p64.weeks % 10
+	if w10 != 0 && (p64.days != 0 || p64.hours != 0 || p64.minutes != 0 || p64.seconds != 0) {
+		p64.days += w10 * 7
+		p64.weeks = (p64.weeks / 10) * 10
+	}
+
 	d10 := p64.days % 10
 	if d10 != 0 && (p64.hours != 0 || p64.minutes != 0 || p64.seconds != 0) {
 		p64.hours += d10 * 24
@@ -140,3 +202,10 @@ func (p64 *period64) moveFractionToRight() *period64 {
 
 	return p64
 }
+
+func absInt64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
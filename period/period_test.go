@@ -45,7 +45,7 @@ func TestParseErrors(t *testing.T) {
 		// integer overflow
 		{"P2147483648Y", false, ": integer overflow occurred in years", "P2147483648Y"},
 		{"P2147483648M", false, ": integer overflow occurred in months", "P2147483648M"},
-		{"P2147483648W", false, ": integer overflow occurred in days", "P2147483648W"},
+		{"P2147483648W", false, ": integer overflow occurred in weeks", "P2147483648W"},
 		{"P2147483648D", false, ": integer overflow occurred in days", "P2147483648D"},
 		{"PT2147483648H", false, ": integer overflow occurred in hours", "PT2147483648H"},
 		{"PT2147483648M", false, ": integer overflow occurred in minutes", "PT2147483648M"},
@@ -89,11 +89,11 @@ func TestParsePeriodWithNormalise(t *testing.T) {
 		{"-PT11592000S", "-PT3220H", Period{hours: -32200}},
 		{"PT11595599S", "PT3220H59M59S", Period{hours: 32200, minutes: 590, seconds: 590}},
 		// largest possible number of seconds normalised only in days, hours, mins, sec
-		{"PT283046400S", "P468W", Period{days: 32760}},
-		{"-PT283046400S", "-P468W", Period{days: -32760}},
-		{"PT43084443590S", "P1365Y3M2WT26H83M50S", Period{years: 13650, months: 30, days: 140, hours: 260, minutes: 830, seconds: 500}},
+		{"PT283046400S", "P3276D", Period{days: 32760}},
+		{"-PT283046400S", "-P3276D", Period{days: -32760}},
+		{"PT43084443590S", "P1365Y3M14DT26H83M50S", Period{years: 13650, months: 30, days: 140, hours: 260, minutes: 830, seconds: 500}},
 		{"PT103412159999S", "P3276Y11M29DT39H107M59S", Period{years: 32760, months: 110, days: 290, hours: 390, minutes: 1070, seconds: 590}},
-		{"PT283132799S", "P468WT23H59M59S", Period{days: 32760, hours: 230, minutes: 590, seconds: 590}},
+		{"PT283132799S", "P3276DT23H59M59S", Period{days: 32760, hours: 230, minutes: 590, seconds: 590}},
 		// other examples are in TestNormalise
 	}
 	for i, c := range cases {
@@ -130,7 +130,7 @@ func TestParsePeriodWithoutNormalise(t *testing.T) {
 		// ones
 		{"P1Y", "P1Y", Period{years: 10}},
 		{"P1M", "P1M", Period{months: 10}},
-		{"P1W", "P1W", Period{days: 70}},
+		{"P1W", "P1W", Period{weeks: 10}},
 		{"P1D", "P1D", Period{days: 10}},
 		{"PT1H", "PT1H", Period{hours: 10}},
 		{"PT1M", "PT1M", Period{minutes: 10}},
@@ -148,9 +148,8 @@ func TestParsePeriodWithoutNormalise(t *testing.T) {
 		{"-PT0.1M", "-PT0.1M", Period{minutes: -1}},
 		{"PT0.1S", "PT0.1S", Period{seconds: 1}},
 		{"-PT0.1S", "-PT0.1S", Period{seconds: -1}},
-		// week special case: also not identity when reversed
-		{"P0.1W", "P0.7D", Period{days: 7}},
-		{"-P0.1W", "-P0.7D", Period{days: -7}},
+		{"P0.1W", "P0.1W", Period{weeks: 1}},
+		{"-P0.1W", "-P0.1W", Period{weeks: -1}},
 		// largest
 		{"PT107374182.4S", "PT107374182.4S", Period{seconds: 1073741824}},
 		{"PT107374182.4M", "PT107374182.4M", Period{minutes: 1073741824}},
@@ -161,7 +160,10 @@ func TestParsePeriodWithoutNormalise(t *testing.T) {
 
 		{"P3Y", "P3Y", Period{years: 30}},
 		{"P6M", "P6M", Period{months: 60}},
-		{"P5W", "P5W", Period{days: 350}},
+		{"P5W", "P5W", Period{weeks: 50}},
+		{"P14D", "P14D", Period{days: 140}},
+		{"P1W3D", "P1W3D", Period{weeks: 10, days: 30}},
+		{"P1W7D", "P1W7D", Period{weeks: 10, days: 70}},
 		{"P4D", "P4D", Period{days: 40}},
 		{"PT12H", "PT12H", Period{hours: 120}},
 		{"PT30M", "PT30M", Period{minutes: 300}},
@@ -174,10 +176,10 @@ func TestParsePeriodWithoutNormalise(t *testing.T) {
 		{"P1Y2.5M", "P1Y2.5M", Period{years: 10, months: 25}},
 		{"P1Y2.15M", "P1Y2.1M", Period{years: 10, months: 21}},
 		// others
-		{"P3Y6M5W4DT12H40M5S", "P3Y6M39DT12H40M5S", Period{years: 30, months: 60, days: 390, hours: 120, minutes: 400, seconds: 50}},
-		{"+P3Y6M5W4DT12H40M5S", "P3Y6M39DT12H40M5S", Period{years: 30, months: 60, days: 390, hours: 120, minutes: 400, seconds: 50}},
-		{"-P3Y6M5W4DT12H40M5S", "-P3Y6M39DT12H40M5S", Period{years: -30, months: -60, days: -390, hours: -120, minutes: -400, seconds: -50}},
-		{"P1Y14M35DT48H125M800S", "P1Y14M5WT48H125M800S", Period{years: 10, months: 140, days: 350, hours: 480, minutes: 1250, seconds: 8000}},
+		{"P3Y6M5W4DT12H40M5S", "P3Y6M5W4DT12H40M5S", Period{years: 30, months: 60, weeks: 50, days: 40, hours: 120, minutes: 400, seconds: 50}},
+		{"+P3Y6M5W4DT12H40M5S", "P3Y6M5W4DT12H40M5S", Period{years: 30, months: 60, weeks: 50, days: 40, hours: 120, minutes: 400, seconds: 50}},
+		{"-P3Y6M5W4DT12H40M5S", "-P3Y6M5W4DT12H40M5S", Period{years: -30, months: -60, weeks: -50, days: -40, hours: -120, minutes: -400, seconds: -50}},
+		{"P1Y14M35DT48H125M800S", "P1Y14M35DT48H125M800S", Period{years: 10, months: 140, days: 350, hours: 480, minutes: 1250, seconds: 8000}},
 	}
 	for i, c := range cases {
 		p, err := Parse(c.value, false)
@@ -205,7 +207,7 @@ func TestPeriodString(t *testing.T) {
 		// ones
 		{"P1Y", Period{years: 10}},
 		{"P1M", Period{months: 10}},
-		{"P1W", Period{days: 70}},
+		{"P1W", Period{weeks: 10}},
 		{"P1D", Period{days: 10}},
 		{"PT1H", Period{hours: 10}},
 		{"PT1M", Period{minutes: 10}},
@@ -221,8 +223,10 @@ func TestPeriodString(t *testing.T) {
 
 		{"P3Y", Period{years: 30}},
 		{"P6M", Period{months: 60}},
-		{"P5W", Period{days: 350}},
-		{"P4W", Period{days: 280}},
+		{"P5W", Period{weeks: 50}},
+		{"P4W", Period{weeks: 40}},
+		{"P28D", Period{days: 280}},
+		{"P1W3D", Period{weeks: 10, days: 30}},
 		{"P4D", Period{days: 40}},
 		{"PT12H", Period{hours: 120}},
 		{"PT30M", Period{minutes: 300}},
@@ -1106,3 +1110,192 @@ func nospace(s string) string {
 	}
 	return b.String()
 }
+
+//-------------------------------------------------------------------------------------------------
+
+func TestParseMixedSignPeriods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value    string
+		reversed string
+		period   Period
+	}{
+		{"P1M-1D", "P1M-1D", Period{months: 10, days: -10}},
+		{"P-1M1D", "P-1M1D", Period{months: -10, days: 10}},
+		{"-P1M-1D", "P-1M1D", Period{months: -10, days: 10}},
+		{"-P-1M-1D", "P1M1D", Period{months: 10, days: 10}},
+		{"P+1M-1D", "P1M-1D", Period{months: 10, days: -10}},
+		{"P1Y-2W", "P1Y-2W", Period{years: 10, weeks: -20}},
+		{"P1DT-1H", "P1DT-1H", Period{days: 10, hours: -10}},
+		{"PT-0.5S", "-PT0.5S", Period{seconds: -5}},
+		{"P2Y-3M4DT-5H6M-7.5S", "P2Y-3M4DT-5H6M-7.5S", Period{years: 20, months: -30, days: 40, hours: -50, minutes: 60, seconds: -75}},
+	}
+	for i, c := range cases {
+		p, err := Parse(c.value, false)
+		s := info(i, c.value)
+		g.Expect(err).NotTo(HaveOccurred(), s)
+		g.Expect(p).To(Equal(c.period), s)
+		g.Expect(p.String()).To(Equal(c.reversed), s+" reversed")
+		g.Expect(MustParse(p.String(), false)).To(Equal(p), s+" round trip")
+	}
+
+	bad := []struct {
+		value    string
+		expected string
+	}{
+		{"P-", "P-: missing designator at the end"},
+		{"P-xD", "P-xD: expected a number but found 'x'"},
+		{"P--1D", "P--1D: expected a number but found '-'"},
+		{"P1M-2147483648D", "P1M-2147483648D: integer overflow occurred in days"},
+	}
+	for i, c := range bad {
+		_, err := Parse(c.value, false)
+		g.Expect(err).To(HaveOccurred(), info(i, c.value))
+		g.Expect(err.Error()).To(Equal(c.expected), info(i, c.value))
+	}
+}
+
+func TestNewMixedSignPeriod(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(New(0, 1, -1, 0, 0, 0)).To(Equal(MustParse("P1M-1D", false)))
+	g.Expect(NewYMD(1, -1, 0)).To(Equal(MustParse("P1Y-1M", false)))
+	g.Expect(NewHMS(-1, 30, 0)).To(Equal(MustParse("PT-1H30M", false)))
+}
+
+func TestMixedSignPeriodSign(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value string
+		sign  int
+		abs   string
+	}{
+		{"P1M-1D", 1, "P1M-1D"},
+		{"P-1M1D", -1, "P1M-1D"},
+		{"P1DT-25H", -1, "P-1DT25H"},
+		{"PT1H-60M", 0, "PT1H-60M"},
+	}
+	for i, c := range cases {
+		p := MustParse(c.value, false)
+		g.Expect(p.IsMixed()).To(BeTrue(), info(i, c.value))
+		g.Expect(p.Sign()).To(Equal(c.sign), info(i, c.value))
+		g.Expect(p.IsPositive()).To(Equal(c.sign > 0), info(i, c.value))
+		g.Expect(p.IsNegative()).To(Equal(c.sign < 0), info(i, c.value))
+		g.Expect(p.Abs().String()).To(Equal(c.abs), info(i, c.value))
+	}
+
+	g.Expect(MustParse("P1M1D").IsMixed()).To(BeFalse())
+	g.Expect(MustParse("-P1M1D").IsMixed()).To(BeFalse())
+}
+
+func TestNormaliseMixedSignPeriods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value     string
+		precise   string
+		imprecise string
+	}{
+		{"P1M-1D", "P1M-1D", "P29DT10H29M6S"},
+		{"P1Y-1M", "P11M", "P11M"},
+		{"P-1Y13M", "P1M", "P1M"},
+		{"PT1H-10M", "PT50M", "PT50M"},
+		{"PT-1H30M", "-PT30M", "-PT30M"},
+		{"PT1M-0.5S", "PT59.5S", "PT59.5S"},
+		{"P1DT-1H", "P1DT-1H", "PT23H"},
+		{"P1Y-1MT1H-10M", "P11MT50M", "P11MT50M"},
+		{"P1M-31D", "P1M-31D", "-PT13H30M54S"},
+		{"P1Y-1D", "P1Y-1D", "P11M29DT7H73M12S"},
+	}
+	for i, c := range cases {
+		p := MustParse(c.value, false)
+		g.Expect(p.Normalise(true).String()).To(Equal(c.precise), info(i, c.value))
+		g.Expect(p.Normalise(false).String()).To(Equal(c.imprecise), info(i, c.value))
+	}
+
+	// parsing normalises precisely by default
+	g.Expect(MustParse("PT1H-10M").String()).To(Equal("PT50M"))
+	g.Expect(MustParse("P1M-1D").String()).To(Equal("P1M-1D"))
+}
+
+func TestFormatMixedSignPeriods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(MustParse("P1M-1D").Format()).To(Equal("1 month, -1 day"))
+	g.Expect(MustParse("P1M-2W").Format()).To(Equal("1 month, -2 weeks"))
+	g.Expect(MustParse("P1M-9D").Format()).To(Equal("1 month, -1 week, -2 days"))
+	g.Expect(MustParse("P-1M1D").Format()).To(Equal("1 month, -1 day"))
+	g.Expect(MustParse("P1DT-1.5H").FormatWithoutWeeks()).To(Equal("1 day, -1.5 hours"))
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func TestPeriodWeeksAndDays(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value          string
+		ew, ed, w, d   int
+		weeks, days    string
+		durationInDays int
+	}{
+		{value: "P2W", ew: 2, w: 2, d: 14, weeks: "P2W", days: "P14D", durationInDays: 14},
+		{value: "P14D", ed: 14, w: 2, d: 14, weeks: "P2W", days: "P14D", durationInDays: 14},
+		{value: "P1W3D", ew: 1, ed: 3, w: 1, d: 10, weeks: "P1W3D", days: "P10D", durationInDays: 10},
+		{value: "P10D", ed: 10, w: 1, d: 10, weeks: "P1W3D", days: "P10D", durationInDays: 10},
+		{value: "P1W7D", ew: 1, ed: 7, w: 2, d: 14, weeks: "P2W", days: "P14D", durationInDays: 14},
+		{value: "P1Y2WT1H", ew: 2, w: 2, d: 14, weeks: "P1Y2WT1H", days: "P1Y14DT1H", durationInDays: 379},
+	}
+	for i, c := range cases {
+		p := MustParse(c.value)
+		g.Expect(p.String()).To(Equal(c.value), info(i, c.value))
+		g.Expect(p.ExplicitWeeks()).To(Equal(c.ew), info(i, c.value))
+		g.Expect(p.ExplicitDays()).To(Equal(c.ed), info(i, c.value))
+		g.Expect(p.Weeks()).To(Equal(c.w), info(i, c.value))
+		g.Expect(p.Days()).To(Equal(c.d), info(i, c.value))
+		g.Expect(p.PreferWeeks().String()).To(Equal(c.weeks), info(i, c.value))
+		g.Expect(p.PreferDays().String()).To(Equal(c.days), info(i, c.value))
+		g.Expect(int(p.DurationApprox()/(24*time.Hour))).To(Equal(c.durationInDays), info(i, c.value))
+
+		n := p.Negate()
+		g.Expect(n.String()).To(Equal("-"+c.value), info(i, c.value))
+		g.Expect(n.ExplicitWeeks()).To(Equal(-c.ew), info(i, c.value))
+		g.Expect(n.Days()).To(Equal(-c.d), info(i, c.value))
+		g.Expect(n.PreferWeeks().String()).To(Equal("-"+c.weeks), info(i, c.value))
+	}
+
+	g.Expect(NewYMWD(1, 2, 3, 4).String()).To(Equal("P1Y2M3W4D"))
+	g.Expect(MustParse("P1.5W").ExplicitWeeksFloat()).To(Equal(float32(1.5)))
+	g.Expect(MustParse("P1.5W").DaysFloat()).To(Equal(float32(10.5)))
+	g.Expect(MustParse("P1W2.5D").ExplicitDaysFloat()).To(Equal(float32(2.5)))
+}
+
+func TestNormaliseAndSimplifyWeeks(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		value     string
+		precise   string
+		imprecise string
+	}{
+		{"P2W", "P2W", "P14D"},
+		{"P1W3D", "P1W3D", "P10D"},
+		{"P5W", "P5W", "P1M4.5D"},
+		{"P1.5W", "P1.5W", "P10.5D"},
+		{"P1.5WT1H", "P1W3DT13H", "P10DT13H"},
+		{"P1W-1D", "P6D", "P6D"},
+		{"P1WT-1H", "P1WT-1H", "P6DT23H"},
+	}
+	for i, c := range cases {
+		p := MustParse(c.value, false)
+		g.Expect(p.Normalise(true).String()).To(Equal(c.precise), info(i, c.value))
+		g.Expect(p.Normalise(false).String()).To(Equal(c.imprecise), info(i, c.value))
+	}
+
+	g.Expect(MustParse("P1WT2H").Simplify(false).String()).To(Equal("P1WT2H"))
+	g.Expect(MustParse("P1DT2H").Simplify(false).String()).To(Equal("PT26H"))
+	g.Expect(MustParse("P1Y2W", false).Simplify(false).String()).To(Equal("P1Y2W"))
+	g.Expect(MustParse("P5WT0.5H").Simplify(false).String()).To(Equal("P5W"))
+}
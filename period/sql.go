@@ -34,3 +34,29 @@ func (period *Period) Scan(value interface{}) (err error) {
 func (period Period) Value() (driver.Value, error) {
 	return period.String(), nil
 }
+
+// Scan parses some value, which can be either string or []byte.
+// It implements sql.Scanner, https://golang.org/pkg/database/sql/#Scanner
+func (n *Nano) Scan(value interface{}) (err error) {
+	if value == nil {
+		return nil
+	}
+
+	err = nil
+	switch v := value.(type) {
+	case []byte:
+		*n, err = ParseNano(string(v), false)
+	case string:
+		*n, err = ParseNano(v, false)
+	default:
+		err = fmt.Errorf("%T %+v is not a meaningful period", value, value)
+	}
+
+	return err
+}
+
+// Value converts the period to a string. It implements driver.Valuer,
+// https://golang.org/pkg/database/sql/driver/#Valuer
+func (n Nano) Value() (driver.Value, error) {
+	return n.String(), nil
+}
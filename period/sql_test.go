@@ -25,6 +25,10 @@ func TestPeriodScan(t *testing.T) {
 		// matches the stored value
 		{[]byte("P48M"), MustParse("P48M", false)},
 		{"P48M", MustParse("P48M", false)},
+
+		// mixed-sign periods
+		{[]byte("P1M-1D"), New(0, 1, -1, 0, 0, 0)},
+		{"PT1H-10M", New(0, 0, 0, 1, -10, 0)},
 	}
 
 	for _, c := range cases {
@@ -15,6 +15,9 @@ import (
 // SQL database by implementing the database/sql/driver interfaces.
 // The underlying column type can be an integer (period of days since the epoch),
 // a string, or a DATE.
+//
+// Note that integer columns holding YYYYMMDD values (e.g. 20261016) should use
+// DateInt instead; Date would treat these as a number of days since the epoch.
 
 // Scan parses some value. It implements sql.Scanner,
 // https://golang.org/pkg/database/sql/#Scanner
@@ -94,6 +97,102 @@ func (ds DateString) Value() (driver.Value, error) {
 
 //-------------------------------------------------------------------------------------------------
 
+// DateInt alters Date to make database storage use an integer column holding
+// the date in the form YYYYMMDD, e.g. 20261016. This is a common convention in
+// data warehouses. (Otherwise, Date is stored as an integer number of days since
+// the epoch.)
+type DateInt Date
+
+// Date provides a simple fluent type conversion to the underlying type.
+func (di DateInt) Date() Date {
+	return Date(di)
+}
+
+// DateInt provides a simple fluent type conversion from the underlying type.
+func (d Date) DateInt() DateInt {
+	return DateInt(d)
+}
+
+// Scan parses some value. It implements sql.Scanner,
+// https://golang.org/pkg/database/sql/#Scanner
+// Integers and strings of digits are treated as YYYYMMDD values. Other strings
+// are parsed as per AutoParse.
+func (di *DateInt) Scan(value interface{}) (err error) {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case int64:
+		var d Date
+		d, err = FromYYYYMMDD(int(v))
+		if err == nil {
+			*di = DateInt(d)
+		}
+	case []byte:
+		return di.scanString(string(v))
+	case string:
+		return di.scanString(v)
+	default:
+		return (*Date)(di).scanAny(value)
+	}
+
+	return err
+}
+
+func (di *DateInt) scanString(value string) (err error) {
+	var d Date
+	n, err := strconv.Atoi(value)
+	if err == nil {
+		d, err = FromYYYYMMDD(n)
+	} else {
+		d, err = AutoParse(value)
+	}
+	if err == nil {
+		*di = DateInt(d)
+	}
+	return err
+}
+
+// Value converts the value to an int64 of the form YYYYMMDD. It implements driver.Valuer,
+// https://golang.org/pkg/database/sql/driver/#Valuer
+func (di DateInt) Value() (driver.Value, error) {
+	return int64(di.Date().YYYYMMDD()), nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Scan parses some value, such as from an SQL TIMESTAMP WITHOUT TIME ZONE column.
+// It implements sql.Scanner, https://golang.org/pkg/database/sql/#Scanner
+// A time.Time gives its own wall-clock date and time, whatever its location; strings
+// are parsed as per ParseLocalDateTime.
+func (ldt *LocalDateTime) Scan(value interface{}) (err error) {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		*ldt, err = ParseLocalDateTime(string(v))
+	case string:
+		*ldt, err = ParseLocalDateTime(v)
+	case time.Time:
+		*ldt = LocalDateTimeOf(v)
+	default:
+		err = fmt.Errorf("%T %+v is not a meaningful date-time", value, value)
+	}
+	return err
+}
+
+// Value converts the value to a time.Time in UTC having the same wall-clock date and time,
+// which drivers store as-is in a TIMESTAMP WITHOUT TIME ZONE column.
+// It implements driver.Valuer, https://golang.org/pkg/database/sql/driver/#Valuer
+func (ldt LocalDateTime) Value() (driver.Value, error) {
+	return ldt.UTC(), nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
 // DisableTextStorage reduces the Scan method so that only integers are handled.
 // Normally, database types int64, []byte, string and time.Time are supported.
 // When set true, only int64 is supported; this mode allows optimisation of SQL
@@ -7,6 +7,7 @@ package date
 import (
 	"database/sql/driver"
 	"testing"
+	"time"
 )
 
 func TestDateScan(t *testing.T) {
@@ -88,6 +89,62 @@ func TestDateStringScan(t *testing.T) {
 	}
 }
 
+func TestDateIntScan(t *testing.T) {
+	cases := []struct {
+		v        interface{}
+		expected string
+		value    int64
+	}{
+		{int64(20261016), "2026-10-16", 20261016},
+		{int64(19700101), "1970-01-01", 19700101},
+		{"20181231", "2018-12-31", 20181231},
+		{[]byte("20181231"), "2018-12-31", 20181231},
+		{"2018-12-31", "2018-12-31", 20181231},
+		{"31/12/2018", "2018-12-31", 20181231},
+		{New(2018, time.December, 31).Local(), "2018-12-31", 20181231},
+	}
+
+	for i, c := range cases {
+		r := new(DateInt)
+		e := r.Scan(c.v)
+		if e != nil {
+			t.Errorf("%d: Got %v for %s", i, e, c.expected)
+		}
+		if r.Date().String() != c.expected {
+			t.Errorf("%d: Got %v, want %s", i, r.Date(), c.expected)
+		}
+
+		var d driver.Valuer = *r
+
+		q, e := d.Value()
+		if e != nil {
+			t.Errorf("%d: Got %v for %s", i, e, c.expected)
+		}
+		if q.(int64) != c.value {
+			t.Errorf("%d: Got %v, want %d", i, q, c.value)
+		}
+	}
+}
+
+func TestDateIntScanWithJunk(t *testing.T) {
+	cases := []struct {
+		v        interface{}
+		expected string
+	}{
+		{true, "bool true is not a meaningful date"},
+		{int64(17896), "Date.FromYYYYMMDD: cannot convert 17896: invalid month"},
+		{"20261032", "Date.FromYYYYMMDD: cannot convert 20261032: invalid day"},
+	}
+
+	for i, c := range cases {
+		r := new(DateInt)
+		e := r.Scan(c.v)
+		if e == nil || e.Error() != c.expected {
+			t.Errorf("%d: Got %v, want %q", i, e, c.expected)
+		}
+	}
+}
+
 func TestDateScanWithJunk(t *testing.T) {
 	cases := []struct {
 		v        interface{}
@@ -139,3 +196,36 @@ func TestDateAsStringScanWithNil(t *testing.T) {
 		t.Errorf("Got %v", e)
 	}
 }
+
+func TestLocalDateTimeScan(t *testing.T) {
+	want := MustParseLocalDateTime("2026-10-16T09:30:15.250")
+	cases := []interface{}{
+		"2026-10-16 09:30:15.25",
+		[]byte("2026-10-16T09:30:15.250"),
+		time.Date(2026, time.October, 16, 9, 30, 15, 250000000, time.UTC),
+		time.Date(2026, time.October, 16, 9, 30, 15, 250000000, time.FixedZone("", -7200)),
+	}
+
+	for i, c := range cases {
+		var ldt LocalDateTime
+		if e := ldt.Scan(c); e != nil {
+			t.Errorf("%d: Got %v", i, e)
+		} else if ldt != want {
+			t.Errorf("%d: Got %v, want %v", i, ldt, want)
+		}
+	}
+
+	var d driver.Valuer = want
+	q, e := d.Value()
+	if e != nil || !q.(time.Time).Equal(time.Date(2026, time.October, 16, 9, 30, 15, 250000000, time.UTC)) {
+		t.Errorf("Got %v %v", q, e)
+	}
+
+	var ldt LocalDateTime
+	if e := ldt.Scan(true); e == nil || e.Error() != "bool true is not a meaningful date-time" {
+		t.Errorf("Got %v", e)
+	}
+	if e := ldt.Scan(nil); e != nil || !ldt.IsZero() {
+		t.Errorf("Got %v %v", ldt, e)
+	}
+}
@@ -5,7 +5,9 @@
 package timespan
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/simplylizz/date"
@@ -265,6 +267,93 @@ func (dateRange DateRange) Merge(otherRange DateRange) DateRange {
 	return NewDateRange(minStart, maxEnd)
 }
 
+// Overlaps reports whether the two date ranges have at least one date in common.
+// Empty date ranges (i.e. zero days) never overlap anything.
+func (dateRange DateRange) Overlaps(other DateRange) bool {
+	if dateRange.days == 0 || other.days == 0 {
+		return false
+	}
+	return dateRange.Start().Before(other.End()) && other.Start().Before(dateRange.End())
+}
+
+// Intersect returns the dates that are in both date ranges. If they don't overlap
+// (see Overlaps), the zero value is returned and ok is false.
+func (dateRange DateRange) Intersect(other DateRange) (result DateRange, ok bool) {
+	if !dateRange.Overlaps(other) {
+		return DateRange{}, false
+	}
+	start := dateRange.Start().Max(other.Start())
+	end := dateRange.End().Min(other.End())
+	return NewDateRange(start, end), true
+}
+
+// Subtract returns the dates that are in this date range but not in the other. The result
+// has no pieces if other contains the whole range, two pieces if other is strictly inside
+// the range, and otherwise one piece. The pieces are normalised and in ascending order;
+// none of them is empty.
+func (dateRange DateRange) Subtract(other DateRange) []DateRange {
+	if dateRange.days == 0 {
+		return nil
+	}
+	if !dateRange.Overlaps(other) {
+		return []DateRange{NewDateRange(dateRange.Start(), dateRange.End())}
+	}
+
+	var pieces []DateRange
+	if dateRange.Start().Before(other.Start()) {
+		pieces = append(pieces, NewDateRange(dateRange.Start(), other.Start()))
+	}
+	if other.End().Before(dateRange.End()) {
+		pieces = append(pieces, NewDateRange(other.End(), dateRange.End()))
+	}
+	return pieces
+}
+
+// Gap returns the dates lying between two date ranges that neither overlap nor abut, i.e.
+// from the end of the earlier range up to the start of the later one. If there are no such
+// dates, the zero value is returned and ok is false.
+func (dateRange DateRange) Gap(other DateRange) (result DateRange, ok bool) {
+	switch {
+	case dateRange.End().Before(other.Start()):
+		return NewDateRange(dateRange.End(), other.Start()), true
+	case other.End().Before(dateRange.Start()):
+		return NewDateRange(other.End(), dateRange.Start()), true
+	}
+	return DateRange{}, false
+}
+
+// Abuts reports whether the two date ranges are adjacent, so that one starts on the end
+// date (i.e. the date after the last date) of the other. Empty date ranges never abut
+// anything.
+func (dateRange DateRange) Abuts(other DateRange) bool {
+	if dateRange.days == 0 || other.days == 0 {
+		return false
+	}
+	return dateRange.End() == other.Start() || other.End() == dateRange.Start()
+}
+
+// ContainsRange tests whether every date in the other date range is also in this date
+// range. An empty other range is contained if its start date is contained (see Contains).
+// Empty date ranges never contain anything.
+func (dateRange DateRange) ContainsRange(other DateRange) bool {
+	if other.days == 0 {
+		return dateRange.Contains(other.Start())
+	}
+	if dateRange.days == 0 {
+		return false
+	}
+	return !other.Start().Before(dateRange.Start()) && !dateRange.End().Before(other.End())
+}
+
+// Clamp restricts the other date range so that it lies within this date range. This is
+// like Intersect, except that when the two don't overlap, the result is an empty range
+// at whichever end of this range is nearer to the other range. The result is normalised.
+func (dateRange DateRange) Clamp(other DateRange) DateRange {
+	start := other.Start().Max(dateRange.Start()).Min(dateRange.End())
+	end := other.End().Max(start).Min(dateRange.End())
+	return NewDateRange(start, end)
+}
+
 // Duration computes the duration (in nanoseconds) from midnight at the start of the date
 // range up to and including the very last nanosecond before midnight on the end day.
 // The calculation is for UTC, which does not have daylight saving and every day has 24 hours.
@@ -287,16 +376,17 @@ func (dateRange DateRange) DurationIn(loc *time.Location) time.Duration {
 	return dateRange.EndTimeIn(loc).Sub(dateRange.StartTimeIn(loc))
 }
 
-// StartTimeIn returns the start time in a specified location.
+// StartTimeIn returns the start time in a specified location. This is the first instant
+// of the start date (see date.Date.StartOfDayIn), which is usually midnight.
 func (dateRange DateRange) StartTimeIn(loc *time.Location) time.Time {
-	return dateRange.Start().In(loc)
+	return dateRange.Start().StartOfDayIn(loc)
 }
 
 // EndTimeIn returns the nanosecond after the end time in a specified location. Along with
 // StartTimeIn, this gives a 'half-open' range where the start is inclusive and the end is
 // exclusive.
 func (dateRange DateRange) EndTimeIn(loc *time.Location) time.Time {
-	return dateRange.End().In(loc)
+	return dateRange.End().StartOfDayIn(loc)
 }
 
 // TimeSpanIn obtains the time span corresponding to the date range in a specified location.
@@ -306,3 +396,223 @@ func (dateRange DateRange) TimeSpanIn(loc *time.Location) TimeSpan {
 	d := dateRange.DurationIn(loc)
 	return TimeSpan{s, d}
 }
+
+// FormatISO formats the date range as an ISO-8601 time interval. It produces a string
+// containing the start and end dates, e.g. "2026-01-01/2026-02-01". Or, if useDuration
+// is true, it returns a string containing the start date and the period in days, e.g.
+// "2026-01-01/P31D". The end date is exclusive, as per the half-open model; see also
+// FormatISOInclusive.
+func (dateRange DateRange) FormatISO(useDuration bool) string {
+	return dateRange.formatISO(useDuration, false)
+}
+
+// FormatISOInclusive is as per FormatISO except that the end date is inclusive, so the
+// whole of January 2026 is "2026-01-01/2026-01-31" rather than "2026-01-01/2026-02-01".
+// This does not affect intervals expressed using a period, such as "2026-01-01/P31D",
+// because the period always gives the length of the range.
+func (dateRange DateRange) FormatISOInclusive(useDuration bool) string {
+	return dateRange.formatISO(useDuration, true)
+}
+
+func (dateRange DateRange) formatISO(useDuration, inclusive bool) string {
+	norm := dateRange.Normalise()
+	if useDuration {
+		return fmt.Sprintf("%s/%s", norm.mark, period.NewYMD(0, 0, int(norm.days)))
+	}
+
+	end := norm.End()
+	if inclusive {
+		end = end.Add(-1)
+	}
+	return fmt.Sprintf("%s/%s", norm.mark, end)
+}
+
+// MustParseISODateRange is as per ParseISODateRange except that it panics if the string
+// cannot be parsed. This is intended for setup code; don't use it for user inputs.
+func MustParseISODateRange(text string) DateRange {
+	dr, err := ParseISODateRange(text)
+	if err != nil {
+		panic(err)
+	}
+	return dr
+}
+
+// ParseISODateRange parses an ISO-8601 time interval as a date range. The string must
+// contain any of
+//
+//     date "/" date
+//     date "/" period
+//     period "/" date
+//
+// for example "2026-01-01/2026-02-01", "2026-01-01/P1M" or "P1W/2026-02-01". The end
+// date is exclusive, as per the half-open model; see also ParseISODateRangeInclusive.
+// The end date can be abbreviated by omitting its leading fields when they are the same
+// as the start date's, so "2026-01-01/02-01" is the same as "2026-01-01/2026-02-01".
+//
+// The dates are parsed using date.ParseISO. The periods must contain only years, months,
+// weeks and days.
+func ParseISODateRange(text string) (DateRange, error) {
+	return parseISODateRange(text, false)
+}
+
+// MustParseISODateRangeInclusive is as per ParseISODateRangeInclusive except that it
+// panics if the string cannot be parsed. This is intended for setup code; don't use it
+// for user inputs.
+func MustParseISODateRangeInclusive(text string) DateRange {
+	dr, err := ParseISODateRangeInclusive(text)
+	if err != nil {
+		panic(err)
+	}
+	return dr
+}
+
+// ParseISODateRangeInclusive is as per ParseISODateRange except that the end date is
+// inclusive, so "2026-01-01/2026-01-31" is the whole of January 2026. This does not
+// affect intervals expressed using a period.
+func ParseISODateRangeInclusive(text string) (DateRange, error) {
+	return parseISODateRange(text, true)
+}
+
+func parseISODateRange(text string, inclusive bool) (DateRange, error) {
+	slash := strings.IndexByte(text, '/')
+	if slash < 0 {
+		return DateRange{}, fmt.Errorf("cannot parse %q because there is no separator '/'", text)
+	}
+
+	first := text[:slash]
+	rest := text[slash+1:]
+
+	if first == "" || rest == "" {
+		return DateRange{}, fmt.Errorf("cannot parse %q because there is no start or end", text)
+	}
+
+	if first[0] == 'P' {
+		pe, err := parseDatePeriod(first, text)
+		if err != nil {
+			return DateRange{}, err
+		}
+
+		end, err := parseEndDate(rest, "", text, inclusive)
+		if err != nil {
+			return DateRange{}, err
+		}
+		return NewDateRange(end.AddPeriod(pe.Negate()), end), nil
+	}
+
+	start, err := date.ParseISO(first)
+	if err != nil {
+		return DateRange{}, fmt.Errorf("cannot parse start date in %q: %s", text, err.Error())
+	}
+
+	if rest[0] == 'P' {
+		pe, err := parseDatePeriod(rest, text)
+		if err != nil {
+			return DateRange{}, err
+		}
+		return NewDateRange(start, start.AddPeriod(pe)), nil
+	}
+
+	end, err := parseEndDate(rest, first, text, inclusive)
+	if err != nil {
+		return DateRange{}, err
+	}
+	return NewDateRange(start, end), nil
+}
+
+func parseDatePeriod(s, text string) (period.Period, error) {
+	pe, err := period.Parse(s, false)
+	if err != nil {
+		return period.Period{}, fmt.Errorf("cannot parse period in %q: %s", text, err.Error())
+	}
+	if !pe.OnlyHMS().IsZero() {
+		return period.Period{}, fmt.Errorf("cannot parse %q because the period has hours, minutes or seconds", text)
+	}
+	return pe, nil
+}
+
+// parseEndDate parses the end date, which is possibly abbreviated, and converts it to
+// the exclusive end date.
+func parseEndDate(s, start, text string, inclusive bool) (date.Date, error) {
+	if len(s) < len(start) {
+		s = start[:len(start)-len(s)] + s
+	}
+
+	end, err := date.ParseISO(s)
+	if err != nil {
+		return date.Date{}, fmt.Errorf("cannot parse end date in %q: %s", text, err.Error())
+	}
+
+	if inclusive {
+		end = end.Add(1)
+	}
+	return end, nil
+}
+
+// MarshalText formats the date range as an ISO-8601 time interval containing the start
+// and end dates (see FormatISO). This implements the encoding.TextMarshaler interface,
+// which also provides support for JSON encoding.
+func (dateRange DateRange) MarshalText() ([]byte, error) {
+	return []byte(dateRange.FormatISO(false)), nil
+}
+
+// UnmarshalText parses an ISO-8601 time interval as a date range (see ParseISODateRange).
+// This implements the encoding.TextUnmarshaler interface, which also provides support
+// for JSON decoding.
+func (dateRange *DateRange) UnmarshalText(text []byte) (err error) {
+	*dateRange, err = ParseISODateRange(string(text))
+	return err
+}
+
+// InclusiveDateRange is a DateRange whose textual form has an inclusive end date, e.g.
+// "2026-01-01/2026-01-31" for the whole of January 2026. It is useful in JSON APIs and
+// configuration files that follow this convention; otherwise it is the same as DateRange.
+type InclusiveDateRange struct {
+	DateRange
+}
+
+// MarshalText formats the date range as an ISO-8601 time interval containing the start
+// and inclusive end dates (see FormatISOInclusive). This implements the
+// encoding.TextMarshaler interface, which also provides support for JSON encoding.
+func (dateRange InclusiveDateRange) MarshalText() ([]byte, error) {
+	return []byte(dateRange.FormatISOInclusive(false)), nil
+}
+
+// UnmarshalText parses an ISO-8601 time interval as a date range with an inclusive end
+// date (see ParseISODateRangeInclusive). This implements the encoding.TextUnmarshaler
+// interface, which also provides support for JSON decoding.
+func (dateRange *InclusiveDateRange) UnmarshalText(text []byte) (err error) {
+	dateRange.DateRange, err = ParseISODateRangeInclusive(string(text))
+	return err
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. This also provides
+// support for gob encoding.
+func (dateRange DateRange) MarshalBinary() ([]byte, error) {
+	norm := dateRange.Normalise()
+	enc, err := norm.mark.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	days := norm.days
+	return append(enc, byte(days>>24), byte(days>>16), byte(days>>8), byte(days)), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. This also provides
+// support for gob decoding.
+func (dateRange *DateRange) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("DateRange.UnmarshalBinary: no data")
+	}
+	if len(data) != 8 {
+		return errors.New("DateRange.UnmarshalBinary: invalid length")
+	}
+
+	var mark date.Date
+	if err := mark.UnmarshalBinary(data[:4]); err != nil {
+		return err
+	}
+
+	days := date.PeriodOfDays(data[7]) | date.PeriodOfDays(data[6])<<8 | date.PeriodOfDays(data[5])<<16 | date.PeriodOfDays(data[4])<<24
+	*dateRange = DateRange{mark, days}
+	return nil
+}
@@ -5,6 +5,9 @@
 package timespan
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -335,6 +338,158 @@ func TestDurationInZoneWithDaylightSaving(t *testing.T) {
 	isEq(t, 0, NewDateRange(d0328, d0331).DurationIn(london), time.Hour*71)
 }
 
+func TestDateRangeFormatISO(t *testing.T) {
+	cases := []struct {
+		dr                   DateRange
+		exclusive, inclusive string
+		withPeriod           string
+	}{
+		{NewMonthOf(2026, time.January), "2026-01-01/2026-02-01", "2026-01-01/2026-01-31", "2026-01-01/P31D"},
+		{DayRange(d0401, 7), "2015-04-01/2015-04-08", "2015-04-01/2015-04-07", "2015-04-01/P7D"},
+		{DayRange(d0408, -7), "2015-04-01/2015-04-08", "2015-04-01/2015-04-07", "2015-04-01/P7D"},
+		{OneDayRange(d0329), "2015-03-29/2015-03-30", "2015-03-29/2015-03-29", "2015-03-29/P1D"},
+		{EmptyRange(d0329), "2015-03-29/2015-03-29", "2015-03-29/2015-03-28", "2015-03-29/P0D"},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.dr.FormatISO(false), c.exclusive)
+		isEq(t, i, c.dr.FormatISO(true), c.withPeriod)
+		isEq(t, i, MustParseISODateRange(c.exclusive), c.dr.Normalise())
+		isEq(t, i, MustParseISODateRange(c.withPeriod), c.dr.Normalise())
+
+		isEq(t, i, c.dr.FormatISOInclusive(false), c.inclusive)
+		isEq(t, i, c.dr.FormatISOInclusive(true), c.withPeriod)
+		isEq(t, i, MustParseISODateRangeInclusive(c.inclusive), c.dr.Normalise())
+		isEq(t, i, MustParseISODateRangeInclusive(c.withPeriod), c.dr.Normalise())
+	}
+}
+
+func TestInclusiveDateRangeJSONMarshalling(t *testing.T) {
+	type config struct {
+		Billing InclusiveDateRange
+		Window  DateRange
+	}
+	jan := NewMonthOf(2026, time.January)
+	want := `{"Billing":"2026-01-01/2026-01-31","Window":"2026-01-01/2026-02-01"}`
+
+	bb, err := json.Marshal(config{InclusiveDateRange{jan}, jan})
+	isEq(t, 0, err, nil)
+	isEq(t, 0, string(bb), want)
+
+	var c config
+	isEq(t, 0, json.Unmarshal(bb, &c), nil)
+	isEq(t, 0, c.Billing.DateRange, jan)
+	isEq(t, 0, c.Window, jan)
+
+	var ir InclusiveDateRange
+	isEq(t, 0, json.Unmarshal([]byte(`"2026-01-01/P1M"`), &ir), nil)
+	isEq(t, 0, ir.DateRange, jan)
+	isEq(t, 0, json.Unmarshal([]byte(`"2026-01-01"`), &ir) != nil, true)
+}
+
+func TestParseISODateRange(t *testing.T) {
+	cases := []struct {
+		text       string
+		start, end Date
+	}{
+		{"2026-01-01/2026-02-01", New(2026, time.January, 1), New(2026, time.February, 1)},
+		{"2026-01-01/02-01", New(2026, time.January, 1), New(2026, time.February, 1)},
+		{"2026-01-01/15", New(2026, time.January, 1), New(2026, time.January, 15)},
+		{"20260101/20260201", New(2026, time.January, 1), New(2026, time.February, 1)},
+		{"2026-01-01/P1M", New(2026, time.January, 1), New(2026, time.February, 1)},
+		{"2026-01-31/P1M", New(2026, time.January, 31), New(2026, time.March, 3)},
+		{"2026-01-01/P1Y2M3D", New(2026, time.January, 1), New(2027, time.March, 4)},
+		{"P1W/2026-02-01", New(2026, time.January, 25), New(2026, time.February, 1)},
+		{"P1M/2026-03-01", New(2026, time.February, 1), New(2026, time.March, 1)},
+		{"2026-02-01/2026-01-01", New(2026, time.January, 1), New(2026, time.February, 1)},
+	}
+
+	for i, c := range cases {
+		dr, err := ParseISODateRange(c.text)
+		isEq(t, i, err, nil, c.text)
+		isEq(t, i, dr.Start(), c.start, c.text)
+		isEq(t, i, dr.End(), c.end, c.text)
+	}
+}
+
+func TestParseISODateRangeErrors(t *testing.T) {
+	cases := []string{
+		"2026-01-01",
+		"2026-01-01/",
+		"/2026-01-01",
+		"2026-XX-01/2026-02-01",
+		"2026-01-01/2026-XX-01",
+		"2026-01-01/P1X",
+		"2026-01-01/PT1H",
+		"P1D/P1D",
+	}
+
+	for _, c := range cases {
+		dr, err := ParseISODateRange(c)
+		if err == nil {
+			t.Errorf("%s: got %v", c, dr)
+		}
+	}
+}
+
+func TestDateRangeJSONMarshalling(t *testing.T) {
+	type holder struct {
+		DR DateRange `json:"dr"`
+	}
+
+	cases := []struct {
+		dr   DateRange
+		want string
+	}{
+		{NewMonthOf(2026, time.January), `{"dr":"2026-01-01/2026-02-01"}`},
+		{DayRange(d0408, -7), `{"dr":"2015-04-01/2015-04-08"}`},
+		{EmptyRange(d0329), `{"dr":"2015-03-29/2015-03-29"}`},
+	}
+
+	for i, c := range cases {
+		bb, err := json.Marshal(holder{c.dr})
+		isEq(t, i, err, nil)
+		isEq(t, i, string(bb), c.want)
+
+		var h holder
+		err = json.Unmarshal(bb, &h)
+		isEq(t, i, err, nil)
+		isEq(t, i, h.DR, c.dr.Normalise())
+	}
+
+	var h holder
+	err := json.Unmarshal([]byte(`{"dr":"2026-01-01/P1M"}`), &h)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, h.DR, NewMonthOf(2026, time.January))
+}
+
+func TestDateRangeBinaryMarshalling(t *testing.T) {
+	cases := []DateRange{
+		{},
+		NewMonthOf(2026, time.January),
+		DayRange(d0408, -7),
+		EmptyRange(New(-11111, time.February, 3)),
+		NewYearOf(12345),
+	}
+
+	for i, c := range cases {
+		var b bytes.Buffer
+		encoder := gob.NewEncoder(&b)
+		decoder := gob.NewDecoder(&b)
+
+		var dr DateRange
+		err := encoder.Encode(&c)
+		isEq(t, i, err, nil)
+		err = decoder.Decode(&dr)
+		isEq(t, i, err, nil)
+		isEq(t, i, dr, c.Normalise())
+	}
+
+	var dr DateRange
+	isEq(t, 0, dr.UnmarshalBinary([]byte{}) != nil, true)
+	isEq(t, 0, dr.UnmarshalBinary([]byte("12345")) != nil, true)
+}
+
 func isEq(t *testing.T, i int, a, b interface{}, msg ...interface{}) {
 	t.Helper()
 	if a != b {
@@ -345,3 +500,144 @@ func isEq(t *testing.T, i int, a, b interface{}, msg ...interface{}) {
 		t.Errorf("%d: %+v is not equal to %+v%s", i, a, b, strings.Join(sa, ""))
 	}
 }
+
+func TestDateRangeInZoneWithoutMidnight(t *testing.T) {
+	// in 2018, the Sao Paulo clocks went forward from 00:00 to 01:00 on 4th November
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip(err)
+	}
+	d1104 := New(2018, time.November, 4)
+	d1105 := New(2018, time.November, 5)
+
+	dr := NewDateRange(d1104, d1105)
+	isEq(t, 0, dr.StartTimeIn(saoPaulo).Equal(time.Date(2018, time.November, 4, 1, 0, 0, 0, saoPaulo)), true, dr.StartTimeIn(saoPaulo))
+	isEq(t, 0, NewAt(dr.StartTimeIn(saoPaulo)), d1104)
+	isEq(t, 0, dr.EndTimeIn(saoPaulo).Equal(time.Date(2018, time.November, 5, 0, 0, 0, 0, saoPaulo)), true, dr.EndTimeIn(saoPaulo))
+	isEq(t, 0, dr.DurationIn(saoPaulo), 23*time.Hour)
+
+	ts := dr.TimeSpanIn(saoPaulo)
+	isEq(t, 0, ts.Duration(), 23*time.Hour)
+	isEq(t, 0, ts.DateRangeIn(saoPaulo), dr)
+
+	// Beirut: the clocks went forward from 00:00 to 01:00 on 31st March 2019
+	beirut, err := time.LoadLocation("Asia/Beirut")
+	if err != nil {
+		t.Skip(err)
+	}
+	dr = OneDayRange(New(2019, time.March, 31))
+	isEq(t, 1, NewAt(dr.StartTimeIn(beirut)), dr.Start())
+	isEq(t, 1, dr.DurationIn(beirut), 23*time.Hour)
+	isEq(t, 1, len(TransitionsIn(dr, beirut)), 1) // the change is at the very start of the day
+	isEq(t, 1, len(TransitionsIn(NewDateRange(dr.Start().Add(-1), dr.End()), beirut)), 1)
+}
+
+func TestDateRangeIntervalOperations(t *testing.T) {
+	cases := []struct {
+		a, b          DateRange
+		overlaps      bool
+		abuts         bool
+		intersect     DateRange
+		gap           DateRange
+		subtract      []DateRange
+		containsRange bool
+		clamp         DateRange
+	}{
+		// b inside a
+		{NewDateRange(d0320, d0401), NewDateRange(d0325, d0327), true, false,
+			NewDateRange(d0325, d0327), DateRange{},
+			[]DateRange{NewDateRange(d0320, d0325), NewDateRange(d0327, d0401)}, true,
+			NewDateRange(d0325, d0327)},
+		// partial overlap at the end
+		{NewDateRange(d0320, d0327), NewDateRange(d0325, d0401), true, false,
+			NewDateRange(d0325, d0327), DateRange{},
+			[]DateRange{NewDateRange(d0320, d0325)}, false,
+			NewDateRange(d0325, d0327)},
+		// partial overlap at the start, with b inverted
+		{NewDateRange(d0325, d0401), DayRange(d0327, -5), true, false,
+			NewDateRange(d0325, d0327), DateRange{},
+			[]DateRange{NewDateRange(d0327, d0401)}, false,
+			NewDateRange(d0325, d0327)},
+		// a inside b
+		{NewDateRange(d0325, d0327), NewDateRange(d0320, d0401), true, false,
+			NewDateRange(d0325, d0327), DateRange{},
+			nil, false,
+			NewDateRange(d0325, d0327)},
+		// adjacent
+		{NewDateRange(d0320, d0325), NewDateRange(d0325, d0401), false, true,
+			DateRange{}, DateRange{},
+			[]DateRange{NewDateRange(d0320, d0325)}, false,
+			EmptyRange(d0325)},
+		// disjoint, b after a
+		{NewDateRange(d0320, d0325), NewDateRange(d0327, d0401), false, false,
+			DateRange{}, NewDateRange(d0325, d0327),
+			[]DateRange{NewDateRange(d0320, d0325)}, false,
+			EmptyRange(d0325)},
+		// disjoint, b before a
+		{NewDateRange(d0327, d0401), NewDateRange(d0320, d0325), false, false,
+			DateRange{}, NewDateRange(d0325, d0327),
+			[]DateRange{NewDateRange(d0327, d0401)}, false,
+			EmptyRange(d0327)},
+		// disjoint, with a inverted
+		{DateRange{d0327, -2}, NewDateRange(d0401, d0404), false, false,
+			DateRange{}, NewDateRange(d0328, d0401),
+			[]DateRange{NewDateRange(d0326, d0328)}, false,
+			EmptyRange(d0328)},
+		// b is empty, inside a
+		{NewDateRange(d0320, d0401), EmptyRange(d0325), false, false,
+			DateRange{}, DateRange{},
+			[]DateRange{NewDateRange(d0320, d0401)}, true,
+			EmptyRange(d0325)},
+		// b is empty, at the exclusive end of a
+		{NewDateRange(d0320, d0401), EmptyRange(d0401), false, false,
+			DateRange{}, DateRange{},
+			[]DateRange{NewDateRange(d0320, d0401)}, false,
+			EmptyRange(d0401)},
+		// a is empty
+		{EmptyRange(d0325), NewDateRange(d0320, d0401), false, false,
+			DateRange{}, DateRange{},
+			nil, false,
+			EmptyRange(d0325)},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.a.Overlaps(c.b), c.overlaps, c.a, c.b)
+		isEq(t, i, c.b.Overlaps(c.a), c.overlaps, c.a, c.b)
+		isEq(t, i, c.a.Abuts(c.b), c.abuts, c.a, c.b)
+		isEq(t, i, c.b.Abuts(c.a), c.abuts, c.a, c.b)
+
+		r, ok := c.a.Intersect(c.b)
+		isEq(t, i, r, c.intersect, c.a, c.b)
+		isEq(t, i, ok, c.overlaps, c.a, c.b)
+
+		g, ok := c.a.Gap(c.b)
+		isEq(t, i, g, c.gap, c.a, c.b)
+		isEq(t, i, ok, !c.gap.IsZero(), c.a, c.b)
+
+		s := c.a.Subtract(c.b)
+		isEq(t, i, len(s), len(c.subtract), c.a, c.b, s)
+		for j := 0; j < len(s) && j < len(c.subtract); j++ {
+			isEq(t, i, s[j], c.subtract[j], c.a, c.b)
+		}
+
+		isEq(t, i, c.a.ContainsRange(c.b), c.containsRange, c.a, c.b)
+		isEq(t, i, c.a.Clamp(c.b), c.clamp, c.a, c.b)
+	}
+}
+
+func TestDateRangeIntervalOperationsAgreeWithContains(t *testing.T) {
+	a := NewDateRange(d0325, d0401)
+	for _, b := range []DateRange{NewDateRange(d0320, d0327), NewDateRange(d0327, d0328),
+		NewDateRange(d0330, d0409), NewDateRange(d0401, d0404), NewDateRange(d0320, d0325)} {
+		pieces := a.Subtract(b)
+		r, _ := a.Intersect(b)
+		for d := d0320; d.Before(d0410); d = d.Add(1) {
+			inPieces := false
+			for _, p := range pieces {
+				inPieces = inPieces || p.Contains(d)
+			}
+			isEq(t, 0, r.Contains(d), a.Contains(d) && b.Contains(d), a, b, d)
+			isEq(t, 0, inPieces, a.Contains(d) && !b.Contains(d), a, b, d)
+		}
+	}
+}
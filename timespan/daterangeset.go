@@ -0,0 +1,185 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/simplylizz/date"
+)
+
+// DateRangeSet is a set of dates held as date ranges. The ranges are kept normalised,
+// sorted and coalesced, so no two of them overlap or abut and none is empty.
+//
+// DateRangeSet values are immutable: the methods that alter a set return a new set.
+// The zero value is an empty set.
+type DateRangeSet struct {
+	ranges []DateRange
+}
+
+// NewDateRangeSet creates a set containing all the dates in some date ranges. The ranges
+// can be in any order and may overlap; inverted ranges are normalised.
+func NewDateRangeSet(ranges ...DateRange) DateRangeSet {
+	return DateRangeSet{coalesceDateRanges(ranges)}
+}
+
+// coalesceDateRanges returns new normalised ranges, sorted by their start dates, with
+// overlapping and abutting ranges merged together and empty ranges removed.
+func coalesceDateRanges(ranges []DateRange) []DateRange {
+	sorted := make([]DateRange, 0, len(ranges))
+	for _, dr := range ranges {
+		if dr.days != 0 {
+			sorted = append(sorted, NewDateRange(dr.Start(), dr.End()))
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].mark.Before(sorted[j].mark)
+	})
+
+	var result []DateRange
+	for _, dr := range sorted {
+		n := len(result)
+		if n > 0 && !result[n-1].End().Before(dr.mark) {
+			result[n-1] = NewDateRange(result[n-1].mark, result[n-1].End().Max(dr.End()))
+		} else {
+			result = append(result, dr)
+		}
+	}
+	return result
+}
+
+// Ranges returns the date ranges in the set, in ascending order.
+func (set DateRangeSet) Ranges() []DateRange {
+	return append([]DateRange(nil), set.ranges...)
+}
+
+// Len returns the number of date ranges in the set.
+func (set DateRangeSet) Len() int {
+	return len(set.ranges)
+}
+
+// IsEmpty returns true if the set contains no dates.
+func (set DateRangeSet) IsEmpty() bool {
+	return len(set.ranges) == 0
+}
+
+// Days returns the total number of dates in the set.
+func (set DateRangeSet) Days() date.PeriodOfDays {
+	var days date.PeriodOfDays
+	for _, dr := range set.ranges {
+		days += dr.days
+	}
+	return days
+}
+
+// Hull returns the smallest date range containing every date in the set. For an empty
+// set, this is the zero value.
+func (set DateRangeSet) Hull() DateRange {
+	if len(set.ranges) == 0 {
+		return DateRange{}
+	}
+	return NewDateRange(set.ranges[0].mark, set.ranges[len(set.ranges)-1].End())
+}
+
+// Contains tests whether the set contains a specified date.
+func (set DateRangeSet) Contains(d date.Date) bool {
+	i := sort.Search(len(set.ranges), func(i int) bool {
+		return d.Before(set.ranges[i].End())
+	})
+	return i < len(set.ranges) && set.ranges[i].Contains(d)
+}
+
+// ContainsRange tests whether the set contains every date in a date range. As with
+// DateRange.ContainsRange, an empty range is contained if its start date is contained.
+func (set DateRangeSet) ContainsRange(dr DateRange) bool {
+	start := dr.Start()
+	i := sort.Search(len(set.ranges), func(i int) bool {
+		return start.Before(set.ranges[i].End())
+	})
+	return i < len(set.ranges) && set.ranges[i].ContainsRange(dr)
+}
+
+// Equal reports whether two sets contain the same dates.
+func (set DateRangeSet) Equal(other DateRangeSet) bool {
+	if len(set.ranges) != len(other.ranges) {
+		return false
+	}
+	for i, dr := range set.ranges {
+		if dr != other.ranges[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Add returns a set containing the dates in this set and those in some date ranges.
+func (set DateRangeSet) Add(ranges ...DateRange) DateRangeSet {
+	return DateRangeSet{coalesceDateRanges(append(set.Ranges(), ranges...))}
+}
+
+// Remove returns a set containing the dates in this set except those in some date ranges.
+func (set DateRangeSet) Remove(ranges ...DateRange) DateRangeSet {
+	return set.Difference(NewDateRangeSet(ranges...))
+}
+
+// Union returns a set containing the dates that are in either set.
+func (set DateRangeSet) Union(other DateRangeSet) DateRangeSet {
+	return set.Add(other.ranges...)
+}
+
+// Intersect returns a set containing the dates that are in both sets.
+func (set DateRangeSet) Intersect(other DateRangeSet) DateRangeSet {
+	var result []DateRange
+	i, j := 0, 0
+	for i < len(set.ranges) && j < len(other.ranges) {
+		a, b := set.ranges[i], other.ranges[j]
+		if r, ok := a.Intersect(b); ok {
+			result = append(result, r)
+		}
+		if a.End().Before(b.End()) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return DateRangeSet{result}
+}
+
+// Difference returns a set containing the dates that are in this set but not the other.
+func (set DateRangeSet) Difference(other DateRangeSet) DateRangeSet {
+	return set.Intersect(other.Complement(set.Hull()))
+}
+
+// Complement returns a set containing the dates within some bounds that are not in this
+// set. For example, if the set holds the days on which data has been loaded, the
+// complement within a quarter gives the days in that quarter that have no data.
+func (set DateRangeSet) Complement(bounds DateRange) DateRangeSet {
+	var result []DateRange
+	cursor, end := bounds.Start(), bounds.End()
+	for _, dr := range set.ranges {
+		if !dr.mark.Before(end) {
+			break
+		}
+		if cursor.Before(dr.mark) {
+			result = append(result, NewDateRange(cursor, dr.mark))
+		}
+		cursor = cursor.Max(dr.End())
+	}
+	if cursor.Before(end) {
+		result = append(result, NewDateRange(cursor, end))
+	}
+	return DateRangeSet{result}
+}
+
+// String lists the date ranges in the set, e.g. "[1 day on 2015-03-20, 2 days from 2015-03-25 to 2015-03-26]".
+func (set DateRangeSet) String() string {
+	s := make([]string, len(set.ranges))
+	for i, dr := range set.ranges {
+		s[i] = dr.String()
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
@@ -0,0 +1,124 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"testing"
+
+	"github.com/simplylizz/date"
+)
+
+func TestNewDateRangeSet(t *testing.T) {
+	cases := []struct {
+		ranges []DateRange
+		want   []DateRange
+	}{
+		{nil, nil},
+		{[]DateRange{EmptyRange(d0320)}, nil},
+		{[]DateRange{NewDateRange(d0325, d0327), NewDateRange(d0320, d0321)},
+			[]DateRange{NewDateRange(d0320, d0321), NewDateRange(d0325, d0327)}},
+		// overlapping and abutting ranges are coalesced
+		{[]DateRange{NewDateRange(d0325, d0328), NewDateRange(d0320, d0326), NewDateRange(d0328, d0330)},
+			[]DateRange{NewDateRange(d0320, d0330)}},
+		// contained ranges are absorbed
+		{[]DateRange{NewDateRange(d0320, d0401), DateRange{d0327, -2}, EmptyRange(d0410)},
+			[]DateRange{NewDateRange(d0320, d0401)}},
+		// inverted ranges hold the same dates as they contain
+		{[]DateRange{DateRange{d0327, -2}},
+			[]DateRange{NewDateRange(d0326, d0328)}},
+	}
+
+	for i, c := range cases {
+		s := NewDateRangeSet(c.ranges...)
+		got := s.Ranges()
+		isEq(t, i, len(got), len(c.want), s)
+		for j := 0; j < len(got) && j < len(c.want); j++ {
+			isEq(t, i, got[j], c.want[j], s)
+		}
+	}
+
+	inverted := DateRange{d0327, -2}
+	s := NewDateRangeSet(inverted)
+	for i, d := range []date.Date{d0325, d0326, d0327, d0328} {
+		isEq(t, i, s.Contains(d), inverted.Contains(d), d)
+	}
+}
+
+func TestDateRangeSetQueries(t *testing.T) {
+	s := NewDateRangeSet(NewDateRange(d0320, d0325), NewDateRange(d0327, d0401), OneDayRange(d0404))
+
+	isEq(t, 0, s.Len(), 3)
+	isEq(t, 0, s.IsEmpty(), false)
+	isEq(t, 0, s.Days(), date.PeriodOfDays(11))
+	isEq(t, 0, s.Hull(), NewDateRange(d0320, d0404.Add(1)))
+	isEq(t, 0, s.String(), "[5 days from 2015-03-20 to 2015-03-24, 5 days from 2015-03-27 to 2015-03-31, 1 day on 2015-04-04]")
+
+	for _, d := range []date.Date{d0320, d0321, d0327, d0331, d0404} {
+		isEq(t, 0, s.Contains(d), true, d)
+	}
+	for _, d := range []date.Date{d0320.Add(-1), d0325, d0326, d0401, d0403, d0407} {
+		isEq(t, 0, s.Contains(d), false, d)
+	}
+
+	isEq(t, 0, s.ContainsRange(NewDateRange(d0327, d0401)), true)
+	isEq(t, 0, s.ContainsRange(NewDateRange(d0321, d0325)), true)
+	isEq(t, 0, s.ContainsRange(NewDateRange(d0321, d0328)), false)
+	isEq(t, 0, s.ContainsRange(EmptyRange(d0327)), true)
+	isEq(t, 0, s.ContainsRange(EmptyRange(d0401)), false)
+
+	var empty DateRangeSet
+	isEq(t, 0, empty.IsEmpty(), true)
+	isEq(t, 0, empty.Days(), date.PeriodOfDays(0))
+	isEq(t, 0, empty.Hull().IsZero(), true)
+	isEq(t, 0, empty.Contains(d0320), false)
+	isEq(t, 0, empty.String(), "[]")
+}
+
+func TestDateRangeSetOperations(t *testing.T) {
+	a := NewDateRangeSet(NewDateRange(d0320, d0326), NewDateRange(d0330, d0403))
+	b := NewDateRangeSet(NewDateRange(d0325, d0331), NewDateRange(d0401, d0402), OneDayRange(d0409))
+
+	isEq(t, 0, a.Union(b).String(), NewDateRangeSet(NewDateRange(d0320, d0403), OneDayRange(d0409)).String())
+	isEq(t, 0, a.Union(b).Equal(b.Union(a)), true)
+
+	isEq(t, 0, a.Intersect(b).String(), NewDateRangeSet(
+		NewDateRange(d0325, d0326), NewDateRange(d0330, d0331), NewDateRange(d0401, d0402)).String())
+	isEq(t, 0, a.Intersect(b).Equal(b.Intersect(a)), true)
+
+	isEq(t, 0, a.Difference(b).String(), NewDateRangeSet(
+		NewDateRange(d0320, d0325), NewDateRange(d0331, d0401), NewDateRange(d0402, d0403)).String())
+	isEq(t, 0, b.Difference(a).String(), NewDateRangeSet(
+		NewDateRange(d0326, d0330), OneDayRange(d0409)).String())
+
+	isEq(t, 0, a.Add(NewDateRange(d0326, d0330)).Equal(NewDateRangeSet(NewDateRange(d0320, d0403))), true)
+	isEq(t, 0, a.Remove(NewDateRange(d0321, d0401)).Equal(NewDateRangeSet(
+		OneDayRange(d0320), NewDateRange(d0401, d0403))), true)
+
+	// the originals are unchanged
+	isEq(t, 0, a.Len(), 2)
+	isEq(t, 0, b.Len(), 3)
+
+	var empty DateRangeSet
+	isEq(t, 0, a.Union(empty).Equal(a), true)
+	isEq(t, 0, a.Intersect(empty).IsEmpty(), true)
+	isEq(t, 0, a.Difference(empty).Equal(a), true)
+	isEq(t, 0, empty.Difference(a).IsEmpty(), true)
+}
+
+func TestDateRangeSetComplement(t *testing.T) {
+	// the days in March with no data loaded
+	loaded := NewDateRangeSet(NewDateRange(d0320, d0326), NewDateRange(d0328, d0403))
+	march := NewMonthOf(2015, 3)
+
+	gaps := loaded.Complement(march)
+	isEq(t, 0, gaps.Equal(NewDateRangeSet(
+		NewDateRange(march.Start(), d0320), NewDateRange(d0326, d0328))), true, gaps)
+	isEq(t, 0, gaps.Days()+loaded.Intersect(NewDateRangeSet(march)).Days(), march.Days())
+
+	isEq(t, 0, loaded.Complement(NewDateRange(d0321, d0325)).IsEmpty(), true)
+	isEq(t, 0, loaded.Complement(EmptyRange(d0326)).IsEmpty(), true)
+	isEq(t, 0, DateRangeSet{}.Complement(march).Equal(NewDateRangeSet(march)), true)
+	isEq(t, 0, loaded.Complement(DateRange{d0409, -10}).Equal(NewDateRangeSet(NewDateRange(d0403, d0410))), true)
+}
@@ -0,0 +1,81 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"time"
+
+	"github.com/simplylizz/date"
+	"github.com/simplylizz/date/clock"
+)
+
+// DayBoundary defines when one business date changes to the next, which is not necessarily
+// at midnight. For example, a hotel's business date might change at 05:00 local time, or
+// a trading day might change at 17:00 New York time.
+//
+// The cutoff is the wall-clock time, relative to midnight at the start of a date, when that
+// business date begins. It can be negative, so a trading day that begins at 17:00 on the
+// previous evening has a cutoff of -07:00 (see clock.New). A zero cutoff gives the usual
+// midnight boundary, as used by date.NewAt and TimeSpan.DateRangeIn.
+type DayBoundary struct {
+	loc    *time.Location
+	cutoff clock.Clock
+}
+
+// NewDayBoundary creates a day boundary at a cutoff clock time in a location. The cutoff
+// should be within 24 hours either side of midnight. If loc is nil, UTC is used.
+func NewDayBoundary(loc *time.Location, cutoff clock.Clock) DayBoundary {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return DayBoundary{loc, cutoff}
+}
+
+// Location returns the location of the day boundary.
+func (db DayBoundary) Location() *time.Location {
+	return db.loc
+}
+
+// Cutoff returns the time of the day boundary, relative to midnight.
+func (db DayBoundary) Cutoff() clock.Clock {
+	return db.cutoff
+}
+
+// DateOf returns the business date that contains a given instant.
+func (db DayBoundary) DateOf(t time.Time) date.Date {
+	d := date.NewAt(t.In(db.loc))
+	if t.Before(db.StartOf(d)) {
+		return d.Add(-1)
+	}
+	if !t.Before(db.StartOf(d.Add(1))) {
+		return d.Add(1)
+	}
+	return d
+}
+
+// StartOf returns the instant at which a business date begins. If the cutoff time does not
+// exist on that date because the clocks go forward, it is moved forward by the same amount,
+// as per date.GapShiftForward; if it occurs twice because the clocks go back, the earlier
+// is used.
+func (db DayBoundary) StartOf(d date.Date) time.Time {
+	if db.cutoff == clock.Midnight {
+		return d.StartOfDayIn(db.loc)
+	}
+	t, _ := date.NewLocalDateTime(d, db.cutoff).In(db.loc, date.GapShiftForward, date.OverlapEarlier)
+	return t
+}
+
+// TimeSpanOf returns the time span of a business date, from its start up to the start of
+// the next business date.
+func (db DayBoundary) TimeSpanOf(d date.Date) TimeSpan {
+	return NewTimeSpan(db.StartOf(d), db.StartOf(d.Add(1))).In(db.loc)
+}
+
+// DateRangeOf obtains the range of business dates corresponding to a time span, as per
+// TimeSpan.DateRangeIn but using the day boundary. The result is normalised.
+func (db DayBoundary) DateRangeOf(ts TimeSpan) DateRange {
+	no := ts.Normalise()
+	return NewDateRange(db.DateOf(no.Start()), db.DateOf(no.End()))
+}
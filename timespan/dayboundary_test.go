@@ -0,0 +1,82 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/simplylizz/date"
+	"github.com/simplylizz/date/clock"
+)
+
+func TestDayBoundaryDateOf(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	hotel := NewDayBoundary(london, clock.New(5, 0, 0, 0))
+	trading := NewDayBoundary(newYork, clock.New(-7, 0, 0, 0))
+	midnight := NewDayBoundary(london, clock.Midnight)
+
+	cases := []struct {
+		db   DayBoundary
+		t    time.Time
+		want Date
+	}{
+		{hotel, lt(27, 4, 59), d0326},
+		{hotel, lt(27, 5, 0), d0327},
+		{hotel, lt(27, 23, 0), d0327},
+		{hotel, lt(28, 0, 30), d0327},
+		{trading, time.Date(2015, time.March, 27, 16, 59, 0, 0, newYork), d0327},
+		{trading, time.Date(2015, time.March, 27, 17, 0, 0, 0, newYork), d0328},
+		{trading, time.Date(2015, time.March, 27, 21, 0, 0, 0, time.UTC), d0328},
+		{trading, time.Date(2015, time.March, 28, 1, 0, 0, 0, newYork), d0328},
+		{midnight, lt(27, 0, 0), d0327},
+		{midnight, lt(27, 23, 59), d0327},
+	}
+	for i, c := range cases {
+		isEq(t, i, c.db.DateOf(c.t), c.want, c.t)
+		isEq(t, i, c.db.TimeSpanOf(c.want).Contains(c.t), true, c.t)
+	}
+}
+
+func TestDayBoundaryStartOf(t *testing.T) {
+	hotel := NewDayBoundary(london, clock.New(5, 0, 0, 0))
+	isEq(t, 0, hotel.Location(), london)
+	isEq(t, 0, hotel.Cutoff(), clock.New(5, 0, 0, 0))
+
+	isEq(t, 0, hotel.StartOf(d0327).Equal(lt(27, 5, 0)), true, hotel.StartOf(d0327))
+
+	// the clocks go forward on Sunday 29th, so the hotel's Saturday has only 23 hours
+	ts := hotel.TimeSpanOf(d0328)
+	isEq(t, 1, ts.Start().Equal(lt(28, 5, 0)), true, ts)
+	isEq(t, 1, ts.End().Equal(lt(29, 5, 0)), true, ts)
+	isEq(t, 1, ts.Duration(), 23*time.Hour)
+	isEq(t, 1, ts.Start().Location(), london)
+
+	// a cutoff in the gap when the clocks go forward is moved forward too
+	night := NewDayBoundary(london, clock.New(1, 30, 0, 0))
+	isEq(t, 2, night.StartOf(d0329).Equal(lt(29, 2, 30)), true, night.StartOf(d0329))
+	isEq(t, 2, night.DateOf(lt(29, 2, 15)), d0328)
+	isEq(t, 2, night.DateOf(lt(29, 2, 30)), d0329)
+
+	// a cutoff in the overlap when the clocks go back uses the earlier instant
+	isEq(t, 3, night.StartOf(d1025).Equal(time.Date(2015, time.October, 25, 0, 30, 0, 0, time.UTC)), true, night.StartOf(d1025))
+	isEq(t, 3, night.TimeSpanOf(New(2015, time.October, 24)).Duration(), 24*time.Hour)
+	isEq(t, 3, night.TimeSpanOf(d1025).Duration(), 25*time.Hour)
+
+	isEq(t, 4, NewDayBoundary(nil, clock.Midnight).StartOf(d0327).Equal(d0327.UTC()), true)
+}
+
+func TestDayBoundaryDateRangeOf(t *testing.T) {
+	hotel := NewDayBoundary(london, clock.New(5, 0, 0, 0))
+
+	dr := hotel.DateRangeOf(NewTimeSpan(lt(27, 12, 0), lt(31, 3, 0)))
+	isEq(t, 0, dr, NewDateRange(d0327, d0330))
+
+	dr = NewDayBoundary(london, clock.Midnight).DateRangeOf(NewTimeSpan(lt(31, 3, 0), lt(27, 12, 0)))
+	isEq(t, 1, dr, NewTimeSpan(lt(27, 12, 0), lt(31, 3, 0)).DateRangeIn(london))
+}
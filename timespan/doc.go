@@ -6,4 +6,18 @@
 // Both are half-open intervals for which the start is included and the end is excluded.
 // This allows for empty spans and also facilitates aggregating spans together.
 //
+// It also provides weekly schedules of opening hours (OpeningHours), which give the
+// time spans during which a shop or service is open.
+//
+// Sets of dates and instants (DateRangeSet and TimeSpanSet) hold sorted, non-overlapping
+// ranges and support union, intersection, difference and complement.
+//
+// Large numbers of date ranges or time spans, each with an associated value, can be held
+// in an index (DateRangeIndex and TimeSpanIndex) that quickly finds those containing a
+// date or instant, or overlapping another range.
+//
+// The relation between any two date ranges or time spans can be classified as one of the
+// thirteen relations of Allen's interval algebra (Relation), and further relations can be
+// inferred using Compose.
+//
 package timespan
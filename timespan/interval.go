@@ -0,0 +1,458 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simplylizz/date"
+	"github.com/simplylizz/date/period"
+)
+
+// ISOFormat is the extended ISO-8601 layout for date & time with a UTC offset (or "Z"),
+// as used by FormatISO. This is the same as time.RFC3339Nano.
+const ISOFormat = time.RFC3339Nano
+
+// FormatISO formats the timespan as an ISO-8601 time interval in the extended format. It
+// produces a string containing the start and end times or, if useDuration is true, the start
+// time and the duration, e.g. "2026-10-16T09:00:00+02:00/PT1H". The times include their UTC
+// offset, or "Z" for UTC; fractional seconds are included only when needed.
+func (ts TimeSpan) FormatISO(useDuration bool) string {
+	return ts.Format(ISOFormat, "/", useDuration)
+}
+
+// MustParseISOInterval is as per ParseISOInterval except that it panics if the string
+// cannot be parsed. This is intended for setup code; don't use it for user inputs.
+func MustParseISOInterval(text string, loc *time.Location) TimeSpan {
+	ts, err := ParseISOInterval(text, loc)
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
+
+// ParseISOInterval parses an ISO-8601 time interval as a timespan. The string must
+// contain any of
+//
+//     time "/" time
+//     time "/" period
+//     period "/" time
+//
+// Times may use the extended format (e.g. "2026-10-16T09:00:00+02:00") or the basic
+// format (e.g. "20261016T090000Z"). Minutes and seconds are optional and the seconds may
+// have a fraction using either '.' or ','. A date without any time means midnight at the
+// start of that date.
+//
+// The end time can be shortened by omitting its leading fields when they are the same as
+// those of the start time, e.g. "2026-10-16T09:00/11:00" or "2026-10-16T09:00/17T10:00".
+// A shortened end time also has the same UTC offset as the start time unless it specifies
+// its own.
+//
+// If a time ends in "Z", its location is UTC; if it ends with a UTC offset, it is in a
+// fixed zone with that offset. Otherwise, the specified location will be used; this
+// behaves the same as time.ParseInLocation.
+//
+// Periods that contain years, months or days are added using the calendar (see time.AddDate),
+// so their duration depends on the start or end time.
+//
+// Repeating intervals are not accepted; use ParseISORepeatingInterval for those.
+func ParseISOInterval(text string, loc *time.Location) (TimeSpan, error) {
+	iv, err := parseISOInterval(text, text, loc)
+	if err != nil {
+		return TimeSpan{}, err
+	}
+	return iv.span, nil
+}
+
+type isoInterval struct {
+	span     TimeSpan
+	period   period.Period // non-zero when the interval was expressed with a period
+	backward bool          // true for the "period/time" form
+}
+
+func parseISOInterval(text, original string, loc *time.Location) (isoInterval, error) {
+	if strings.HasPrefix(text, "R") {
+		return isoInterval{}, fmt.Errorf("cannot parse %q because it is a repeating interval", original)
+	}
+
+	slash := strings.IndexByte(text, '/')
+	if slash < 0 {
+		return isoInterval{}, fmt.Errorf("cannot parse %q because there is no separator '/'", original)
+	}
+
+	first := text[:slash]
+	rest := text[slash+1:]
+
+	if first == "" || rest == "" {
+		return isoInterval{}, fmt.Errorf("cannot parse %q because there is no start or end", original)
+	}
+
+	if first[0] == 'P' {
+		pe, err := period.Parse(first, false)
+		if err != nil {
+			return isoInterval{}, fmt.Errorf("cannot parse period in %q: %s", original, err.Error())
+		}
+
+		et, err := parseISOTime(rest, loc)
+		if err != nil {
+			return isoInterval{}, fmt.Errorf("cannot parse end time in %q: %s", original, err.Error())
+		}
+
+		st := addPeriod(et, pe, -1)
+		return isoInterval{NewTimeSpan(st, et), pe, true}, nil
+	}
+
+	st, err := parseISOTime(first, loc)
+	if err != nil {
+		return isoInterval{}, fmt.Errorf("cannot parse start time in %q: %s", original, err.Error())
+	}
+
+	if rest[0] == 'P' {
+		pe, err := period.Parse(rest, false)
+		if err != nil {
+			return isoInterval{}, fmt.Errorf("cannot parse period in %q: %s", original, err.Error())
+		}
+
+		et := addPeriod(st, pe, 1)
+		return isoInterval{NewTimeSpan(st, et), pe, false}, nil
+	}
+
+	et, err := parseISOTime(expandEndTime(first, rest), st.Location())
+	if err != nil {
+		return isoInterval{}, fmt.Errorf("cannot parse end time in %q: %s", original, err.Error())
+	}
+
+	return isoInterval{span: NewTimeSpan(st, et)}, nil
+}
+
+// expandEndTime fills in the leading fields that have been omitted from a shortened
+// end time by copying them from the start time. When the start has a time, an end
+// without 'T' is only a time (e.g. "11:00" or "1100"); otherwise, it is a date.
+func expandEndTime(start, end string) string {
+	st := strings.IndexByte(start, 'T')
+	et := strings.IndexByte(end, 'T')
+
+	startDate := start
+	if st >= 0 {
+		startDate = start[:st]
+	}
+
+	if et < 0 {
+		if st >= 0 {
+			// only a time is present
+			return startDate + "T" + end
+		}
+		et = len(end)
+	}
+
+	if et < len(startDate) {
+		return startDate[:len(startDate)-et] + end
+	}
+	return end
+}
+
+// addPeriod adds n multiples of the period to a time. The years, months and days are
+// added using the calendar; the hours, minutes and seconds are added as a duration.
+func addPeriod(t time.Time, pe period.Period, n int) time.Time {
+	ymd := pe.OnlyYMD()
+	if ymd.YearsFloat() == float32(pe.Years()) &&
+		ymd.MonthsFloat() == float32(pe.Months()) &&
+		ymd.DaysFloat() == float32(pe.Days()) {
+		hms, _ := pe.OnlyHMS().Duration()
+		return t.AddDate(n*pe.Years(), n*pe.Months(), n*pe.Days()).Add(time.Duration(n) * hms)
+	}
+
+	// fractional years, months or days are approximated
+	d, _ := pe.Duration()
+	return t.Add(time.Duration(n) * d)
+}
+
+// parseISOTime parses an ISO-8601 date-time in either the extended or the basic format,
+// optionally with a UTC offset or zulu indicator.
+func parseISOTime(text string, loc *time.Location) (time.Time, error) {
+	ds := text
+	ts := ""
+	if t := strings.IndexByte(text, 'T'); t >= 0 {
+		ds = text[:t]
+		ts = text[t+1:]
+	}
+
+	d, err := date.ParseISO(ds)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	ts, zone, err := splitZone(ts, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q: %s", text, err.Error())
+	}
+
+	hh, mm, ss, ns, err := parseISOClock(ts)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q: %s", text, err.Error())
+	}
+
+	y, m, dd := d.Date()
+	return time.Date(y, m, dd, hh, mm, ss, ns, zone), nil
+}
+
+// splitZone separates any trailing "Z" or UTC offset from a time.
+func splitZone(ts string, loc *time.Location) (string, *time.Location, error) {
+	if strings.HasSuffix(ts, "Z") {
+		return ts[:len(ts)-1], time.UTC, nil
+	}
+
+	i := strings.LastIndexAny(ts, "+-")
+	if i < 0 {
+		return ts, loc, nil
+	}
+
+	sign := 1
+	if ts[i] == '-' {
+		sign = -1
+	}
+
+	digits := strings.Replace(ts[i+1:], ":", "", 1)
+	if len(digits) != 2 && len(digits) != 4 {
+		return "", nil, fmt.Errorf("invalid UTC offset %q", ts[i:])
+	}
+
+	h, err := strconv.Atoi(digits[:2])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid UTC offset %q", ts[i:])
+	}
+
+	m := 0
+	if len(digits) == 4 {
+		m, err = strconv.Atoi(digits[2:])
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid UTC offset %q", ts[i:])
+		}
+	}
+
+	if h > 18 || m > 59 {
+		return "", nil, fmt.Errorf("UTC offset %q out of range", ts[i:])
+	}
+
+	offset := sign * (h*3600 + m*60)
+	if offset == 0 {
+		return ts[:i], time.UTC, nil
+	}
+	return ts[:i], time.FixedZone("", offset), nil
+}
+
+// parseISOClock parses hh[:mm[:ss[.fff]]] or its basic equivalent hh[mm[ss[.fff]]].
+// The fraction can have any number of digits up to nanosecond resolution.
+func parseISOClock(ts string) (hh, mm, ss, ns int, err error) {
+	if ts == "" {
+		return 0, 0, 0, 0, nil
+	}
+
+	frac := ""
+	if i := strings.IndexAny(ts, ".,"); i >= 0 {
+		frac = ts[i+1:]
+		ts = ts[:i]
+		if frac == "" || len(frac) > 9 {
+			return 0, 0, 0, 0, fmt.Errorf("invalid fraction")
+		}
+	}
+
+	var fields []string
+	if strings.IndexByte(ts, ':') >= 0 {
+		fields = strings.Split(ts, ":")
+	} else {
+		for len(ts) > 2 {
+			fields = append(fields, ts[:2])
+			ts = ts[2:]
+		}
+		fields = append(fields, ts)
+	}
+
+	if len(fields) > 3 || (frac != "" && len(fields) != 3) {
+		return 0, 0, 0, 0, fmt.Errorf("invalid time")
+	}
+
+	values := make([]int, 3)
+	for i, f := range fields {
+		if len(f) != 2 {
+			return 0, 0, 0, 0, fmt.Errorf("invalid time")
+		}
+		values[i], err = strconv.Atoi(f)
+		if err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("invalid time")
+		}
+	}
+
+	if frac != "" {
+		ns, err = strconv.Atoi((frac + "00000000")[:9])
+		if err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("invalid fraction")
+		}
+	}
+
+	if values[0] > 24 || values[1] > 59 || values[2] > 60 ||
+		(values[0] == 24 && (values[1] != 0 || values[2] != 0 || ns != 0)) {
+		return 0, 0, 0, 0, fmt.Errorf("time out of range")
+	}
+
+	return values[0], values[1], values[2], ns, nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// RepeatingTimeSpan is a sequence of consecutive time spans, as specified by an ISO-8601
+// repeating interval such as "R5/2026-10-16T09:00Z/P1D".
+type RepeatingTimeSpan struct {
+	first    TimeSpan
+	period   period.Period
+	repeats  int
+	backward bool
+}
+
+// MustParseISORepeatingInterval is as per ParseISORepeatingInterval except that it panics if
+// the string cannot be parsed. This is intended for setup code; don't use it for user inputs.
+func MustParseISORepeatingInterval(text string, loc *time.Location) RepeatingTimeSpan {
+	r, err := ParseISORepeatingInterval(text, loc)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// ParseISORepeatingInterval parses an ISO-8601 repeating interval. The string must be
+//
+//     "R" n "/" interval
+//
+// where n is the number of repetitions, or is absent for an unbounded number. The interval
+// is as accepted by ParseISOInterval, e.g. "R5/2026-10-16T09:00Z/P1D".
+//
+// Each repetition follows on from the previous one. When the interval is a period followed
+// by an end time, the repetitions run backwards, so the end time is the end of the first
+// time span and each subsequent span is earlier.
+func ParseISORepeatingInterval(text string, loc *time.Location) (RepeatingTimeSpan, error) {
+	if !strings.HasPrefix(text, "R") {
+		return RepeatingTimeSpan{}, fmt.Errorf("cannot parse %q because it does not start with 'R'", text)
+	}
+
+	slash := strings.IndexByte(text, '/')
+	if slash < 0 {
+		return RepeatingTimeSpan{}, fmt.Errorf("cannot parse %q because there is no separator '/'", text)
+	}
+
+	repeats := -1
+	if slash > 1 {
+		n, err := strconv.Atoi(text[1:slash])
+		if err != nil || n < 0 {
+			return RepeatingTimeSpan{}, fmt.Errorf("cannot parse number of repetitions in %q", text)
+		}
+		repeats = n
+	}
+
+	iv, err := parseISOInterval(text[slash+1:], text, loc)
+	if err != nil {
+		return RepeatingTimeSpan{}, err
+	}
+
+	return RepeatingTimeSpan{first: iv.span, period: iv.period, repeats: repeats, backward: iv.backward}, nil
+}
+
+// First returns the first time span.
+func (r RepeatingTimeSpan) First() TimeSpan {
+	return r.first
+}
+
+// Repeats returns the number of repetitions, or -1 if this is unbounded.
+func (r RepeatingTimeSpan) Repeats() int {
+	return r.repeats
+}
+
+// IsUnbounded returns true if the number of repetitions is unbounded.
+func (r RepeatingTimeSpan) IsUnbounded() bool {
+	return r.repeats < 0
+}
+
+// Nth returns the time span for the nth repetition, counting from zero.
+// This is computed directly, without iterating through the earlier repetitions.
+func (r RepeatingTimeSpan) Nth(n int) TimeSpan {
+	if r.period.IsZero() {
+		d := r.first.Duration()
+		if r.backward {
+			return r.first.ShiftBy(-time.Duration(n) * d)
+		}
+		return r.first.ShiftBy(time.Duration(n) * d)
+	}
+
+	if r.backward {
+		end := r.first.End()
+		return NewTimeSpan(addPeriod(end, r.period, -n-1), addPeriod(end, r.period, -n))
+	}
+
+	start := r.first.Start()
+	return NewTimeSpan(addPeriod(start, r.period, n), addPeriod(start, r.period, n+1))
+}
+
+// Iterator returns an iterator that visits each time span in turn. If the number of
+// repetitions is unbounded, so is the iterator.
+func (r RepeatingTimeSpan) Iterator() *TimeSpanIterator {
+	return &TimeSpanIterator{r: r, n: -1}
+}
+
+// FormatISO formats the repeating interval in the ISO-8601 extended format,
+// e.g. "R5/2026-10-16T09:00:00Z/P1D".
+func (r RepeatingTimeSpan) FormatISO() string {
+	n := ""
+	if r.repeats >= 0 {
+		n = strconv.Itoa(r.repeats)
+	}
+
+	switch {
+	case r.period.IsZero():
+		return fmt.Sprintf("R%s/%s", n, r.first.FormatISO(false))
+	case r.backward:
+		return fmt.Sprintf("R%s/%s/%s", n, r.period, r.first.End().Format(ISOFormat))
+	default:
+		return fmt.Sprintf("R%s/%s/%s", n, r.first.Start().Format(ISOFormat), r.period)
+	}
+}
+
+// String is the same as FormatISO.
+func (r RepeatingTimeSpan) String() string {
+	return r.FormatISO()
+}
+
+// TimeSpanIterator visits each time span of a RepeatingTimeSpan in turn. For example
+//
+//     it := r.Iterator()
+//     for it.Next() {
+//         ts := it.TimeSpan()
+//         ...
+//     }
+type TimeSpanIterator struct {
+	r RepeatingTimeSpan
+	n int
+}
+
+// Next advances the iterator to the next time span, which will then be available through
+// the TimeSpan method. It returns false when there are no more time spans.
+func (it *TimeSpanIterator) Next() bool {
+	if !it.r.IsUnbounded() && it.n+1 >= it.r.repeats {
+		return false
+	}
+	it.n++
+	return true
+}
+
+// TimeSpan returns the current time span.
+func (it *TimeSpanIterator) TimeSpan() TimeSpan {
+	return it.r.Nth(it.n)
+}
+
+// Index returns the zero-based index of the current time span.
+func (it *TimeSpanIterator) Index() int {
+	return it.n
+}
@@ -0,0 +1,191 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISOInterval(t *testing.T) {
+	berlin := mustLoadLocation("Europe/Berlin")
+	plus2 := time.FixedZone("", 2*3600)
+	minus0530 := time.FixedZone("", -(5*3600 + 30*60))
+
+	cases := []struct {
+		text     string
+		start    time.Time
+		duration time.Duration
+	}{
+		{"2026-10-16T09:00:00+02:00/PT1H", time.Date(2026, 10, 16, 9, 0, 0, 0, plus2), time.Hour},
+		{"2026-10-16T09:00:00+0200/PT1H", time.Date(2026, 10, 16, 9, 0, 0, 0, plus2), time.Hour},
+		{"2026-10-16T09:00:00+02/PT1H", time.Date(2026, 10, 16, 9, 0, 0, 0, plus2), time.Hour},
+		{"2026-10-16T09:00:00-05:30/PT90M", time.Date(2026, 10, 16, 9, 0, 0, 0, minus0530), 90 * time.Minute},
+		{"2026-10-16T09:00:00.25Z/PT0.5S", time.Date(2026, 10, 16, 9, 0, 0, 250000000, time.UTC), 500 * time.Millisecond},
+		{"2026-10-16T09:00:00,123456789Z/PT1S", time.Date(2026, 10, 16, 9, 0, 0, 123456789, time.UTC), time.Second},
+		{"PT1H/2026-10-16T10:00Z", time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), time.Hour},
+		{"P1D/2026-10-16T10:00Z", time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC), 24 * time.Hour},
+		{"2026-10-16T09:00/11:00", time.Date(2026, 10, 16, 9, 0, 0, 0, berlin), 2 * time.Hour},
+		{"2026-10-16T09:00+02:00/11:00", time.Date(2026, 10, 16, 9, 0, 0, 0, plus2), 2 * time.Hour},
+		{"2026-10-16T09:00Z/17T10:00", time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), 25 * time.Hour},
+		{"2026-10-16T09:00Z/11-01T09:00", time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), 16 * 24 * time.Hour},
+		{"2026-10-16T09:00Z/2026-10-16T11:30+01:00", time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), time.Hour + 30*time.Minute},
+		{"20261016T090000Z/20261016T100000Z", time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), time.Hour},
+		{"20261016T0900/1100", time.Date(2026, 10, 16, 9, 0, 0, 0, berlin), 2 * time.Hour},
+		{"20261016T0900Z/1100Z", time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), 2 * time.Hour},
+		{"20261016T0900Z/17T1000", time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), 25 * time.Hour},
+		{"20261016T090000+0200/103000", time.Date(2026, 10, 16, 9, 0, 0, 0, plus2), 90 * time.Minute},
+		{"2026-10-16/2026-10-17", time.Date(2026, 10, 16, 0, 0, 0, 0, berlin), 24 * time.Hour},
+		{"2026-10-16/17", time.Date(2026, 10, 16, 0, 0, 0, 0, berlin), 24 * time.Hour},
+		{"20261016/1101", time.Date(2026, 10, 16, 0, 0, 0, 0, berlin), 16*24*time.Hour + time.Hour},
+		{"2026-10-16T09:00:00+18:00/PT1H", time.Date(2026, 10, 16, 9, 0, 0, 0, time.FixedZone("", 18*3600)), time.Hour},
+		{"2026-10-16T23:00Z/24:00", time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC), time.Hour},
+		// daylight saving ends on 25th October 2026
+		{"2026-10-24T12:00/P1D", time.Date(2026, 10, 24, 12, 0, 0, 0, berlin), 25 * time.Hour},
+		{"2026-10-24T12:00/PT24H", time.Date(2026, 10, 24, 12, 0, 0, 0, berlin), 24 * time.Hour},
+		{"2026-10-24T12:00/P1DT1H", time.Date(2026, 10, 24, 12, 0, 0, 0, berlin), 26 * time.Hour},
+	}
+
+	for i, c := range cases {
+		ts, err := ParseISOInterval(c.text, berlin)
+		isEq(t, i, err, nil, c.text)
+		if !ts.Start().Equal(c.start) {
+			t.Errorf("%d: %s: got %v, want %v", i, c.text, ts.Start(), c.start)
+		}
+		_, o1 := ts.Start().Zone()
+		_, o2 := c.start.Zone()
+		isEq(t, i, o1, o2, c.text)
+		isEq(t, i, ts.Duration(), c.duration, c.text)
+	}
+}
+
+func TestParseISOIntervalErrors(t *testing.T) {
+	cases := []string{
+		"2026-10-16T09:00:00Z",
+		"2026-10-16T09:00:00Z/",
+		"/PT1H",
+		"PT1H/PT1H",
+		"2026-10-16T09:00:00Z/P1X",
+		"2026-XX-16T09:00:00Z/PT1H",
+		"2026-10-16T09:00:00+2/PT1H",
+		"2026-10-16T09:00:00+25:00/PT1H",
+		"2026-10-16T09:00:00+02:99/PT1H",
+		"2026-10-16T09:00:00-1900/PT1H",
+		"2026-10-16T09:0/PT1H",
+		"2026-10-16T25:00/PT1H",
+		"2026-10-16T09:60/PT1H",
+		"2026-10-16T09:00./PT1H",
+		"2026-10-16T09:00:00Z/2026-10-16T1x:00",
+		"R5/2026-10-16T09:00Z/P1D",
+	}
+
+	for _, c := range cases {
+		ts, err := ParseISOInterval(c, time.UTC)
+		if err == nil {
+			t.Errorf("%s: got %v", c, ts)
+		}
+	}
+}
+
+func TestTSFormatISO(t *testing.T) {
+	plus2 := time.FixedZone("", 2*3600)
+	t0 := time.Date(2026, 10, 16, 9, 0, 0, 0, plus2)
+
+	cases := []struct {
+		ts               TimeSpan
+		withEnd, withDur string
+	}{
+		{TimeSpanOf(t0, time.Hour), "2026-10-16T09:00:00+02:00/2026-10-16T10:00:00+02:00", "2026-10-16T09:00:00+02:00/PT1H"},
+		{TimeSpanOf(t0.UTC(), 90*time.Minute), "2026-10-16T07:00:00Z/2026-10-16T08:30:00Z", "2026-10-16T07:00:00Z/PT1H30M"},
+		{TimeSpanOf(t0.Add(time.Millisecond), time.Second), "2026-10-16T09:00:00.001+02:00/2026-10-16T09:00:01.001+02:00", "2026-10-16T09:00:00.001+02:00/PT1S"},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.ts.FormatISO(false), c.withEnd)
+		isEq(t, i, c.ts.FormatISO(true), c.withDur)
+		isEq(t, i, MustParseISOInterval(c.withEnd, time.UTC).Equal(c.ts), true, c.withEnd)
+		isEq(t, i, MustParseISOInterval(c.withDur, time.UTC).Equal(c.ts), true, c.withDur)
+	}
+}
+
+func TestParseISORepeatingInterval(t *testing.T) {
+	t0 := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		text, formatted string
+		repeats         int
+		starts          []time.Time
+		dur             []time.Duration
+	}{
+		{"R3/2026-10-16T09:00Z/P1D", "R3/2026-10-16T09:00:00Z/P1D", 3,
+			[]time.Time{t0, t0.AddDate(0, 0, 1), t0.AddDate(0, 0, 2)},
+			[]time.Duration{24 * time.Hour, 24 * time.Hour, 24 * time.Hour}},
+		{"R2/2026-10-16T09:00Z/2026-10-16T09:30Z", "R2/2026-10-16T09:00:00Z/2026-10-16T09:30:00Z", 2,
+			[]time.Time{t0, t0.Add(30 * time.Minute)},
+			[]time.Duration{30 * time.Minute, 30 * time.Minute}},
+		{"R3/2026-01-31T09:00Z/P1M", "R3/2026-01-31T09:00:00Z/P1M", 3,
+			[]time.Time{
+				time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC)},
+			[]time.Duration{31 * 24 * time.Hour, 28 * 24 * time.Hour, 31 * 24 * time.Hour}},
+		{"R2/PT1H/2026-10-16T09:00Z", "R2/PT1H/2026-10-16T09:00:00Z", 2,
+			[]time.Time{t0.Add(-time.Hour), t0.Add(-2 * time.Hour)},
+			[]time.Duration{time.Hour, time.Hour}},
+		{"R0/2026-10-16T09:00Z/P1D", "R0/2026-10-16T09:00:00Z/P1D", 0, nil, nil},
+	}
+
+	for i, c := range cases {
+		r, err := ParseISORepeatingInterval(c.text, time.UTC)
+		isEq(t, i, err, nil, c.text)
+		isEq(t, i, r.Repeats(), c.repeats, c.text)
+		isEq(t, i, r.String(), c.formatted, c.text)
+		isEq(t, i, MustParseISORepeatingInterval(c.formatted, time.UTC), r, c.text)
+
+		it := r.Iterator()
+		n := 0
+		for it.Next() {
+			ts := it.TimeSpan()
+			isEq(t, i, it.Index(), n, c.text)
+			if !ts.Start().Equal(c.starts[n]) {
+				t.Errorf("%d: %s: %d got %v, want %v", i, c.text, n, ts.Start(), c.starts[n])
+			}
+			isEq(t, i, ts.Duration(), c.dur[n], c.text, n)
+			n++
+		}
+		isEq(t, i, n, c.repeats, c.text)
+	}
+}
+
+func TestParseISORepeatingIntervalUnbounded(t *testing.T) {
+	r := MustParseISORepeatingInterval("R/2026-10-16T09:00Z/PT1H", time.UTC)
+	isEq(t, 0, r.IsUnbounded(), true)
+	isEq(t, 0, r.String(), "R/2026-10-16T09:00:00Z/PT1H")
+
+	it := r.Iterator()
+	for i := 0; i < 1000; i++ {
+		isEq(t, i, it.Next(), true)
+	}
+	isEq(t, 0, it.TimeSpan().Start(), time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC))
+	isEq(t, 0, r.Nth(999), it.TimeSpan())
+}
+
+func TestParseISORepeatingIntervalErrors(t *testing.T) {
+	cases := []string{
+		"2026-10-16T09:00Z/P1D",
+		"R5",
+		"Rx/2026-10-16T09:00Z/P1D",
+		"R-1/2026-10-16T09:00Z/P1D",
+		"R5/2026-10-16T09:00Z",
+		"R5/R5/2026-10-16T09:00Z/P1D",
+	}
+
+	for _, c := range cases {
+		r, err := ParseISORepeatingInterval(c, time.UTC)
+		if err == nil {
+			t.Errorf("%s: got %v", c, r)
+		}
+	}
+}
@@ -0,0 +1,480 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"sort"
+	"time"
+
+	"github.com/simplylizz/date"
+)
+
+// The interval indexes are persistent treaps ordered by start, then end, then insertion
+// sequence. Each node also holds the greatest end in its subtree, which allows whole
+// subtrees to be skipped by queries. Inserting and deleting copy only the path from the
+// root to the altered node, so existing indexes are never modified.
+
+// endpoint is an endpoint of an interval, either a date (as days since the epoch) or an
+// instant (as Unix seconds and nanoseconds); this covers the whole range of time.Time.
+type endpoint struct {
+	sec  int64
+	nsec int32
+}
+
+func (p endpoint) less(q endpoint) bool {
+	return p.sec < q.sec || p.sec == q.sec && p.nsec < q.nsec
+}
+
+func datePoint(d date.Date) endpoint {
+	return endpoint{sec: int64(d.DaysSinceEpoch())}
+}
+
+func timePoint(t time.Time) endpoint {
+	return endpoint{sec: t.Unix(), nsec: int32(t.Nanosecond())}
+}
+
+type inode struct {
+	start, end  endpoint
+	seq, prio   uint64
+	entry       interface{} // a DateRangeEntry or a TimeSpanEntry
+	maxEnd      endpoint
+	size        int
+	left, right *inode
+}
+
+// priority scrambles a sequence number to give a treap priority; this is deterministic
+// but behaves as if random (it is the SplitMix64 finaliser).
+func priority(seq uint64) uint64 {
+	z := seq + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// before reports whether node a precedes node b in the index order.
+func (a *inode) before(b *inode) bool {
+	switch {
+	case a.start != b.start:
+		return a.start.less(b.start)
+	case a.end != b.end:
+		return a.end.less(b.end)
+	}
+	return a.seq < b.seq
+}
+
+// fix recomputes the aggregate fields after the children have changed.
+func (n *inode) fix() *inode {
+	n.size, n.maxEnd = 1, n.end
+	for _, c := range []*inode{n.left, n.right} {
+		if c != nil {
+			n.size += c.size
+			if n.maxEnd.less(c.maxEnd) {
+				n.maxEnd = c.maxEnd
+			}
+		}
+	}
+	return n
+}
+
+// with returns a copy of n with different children.
+func (n *inode) with(left, right *inode) *inode {
+	c := *n
+	c.left, c.right = left, right
+	return c.fix()
+}
+
+func sizeOf(n *inode) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// buildNodes creates a treap from nodes that are already in index order, in linear time.
+// The nodes are held in a single block in index order, so that queries, which visit
+// neighbouring nodes, make good use of the memory cache.
+func buildNodes(block []inode) *inode {
+	var stack []*inode
+	for i := range block {
+		n := &block[i]
+		var last *inode
+		for len(stack) > 0 && stack[len(stack)-1].prio < n.prio {
+			last = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		}
+		n.left = last
+		if len(stack) > 0 {
+			stack[len(stack)-1].right = n
+		}
+		stack = append(stack, n)
+	}
+	if len(stack) == 0 {
+		return nil
+	}
+	return fixNodes(stack[0])
+}
+
+func fixNodes(n *inode) *inode {
+	if n != nil {
+		fixNodes(n.left)
+		fixNodes(n.right)
+		n.fix()
+	}
+	return n
+}
+
+// splitNodes divides t into the nodes before k and the rest.
+func splitNodes(t, k *inode) (*inode, *inode) {
+	if t == nil {
+		return nil, nil
+	}
+	if t.before(k) {
+		l, r := splitNodes(t.right, k)
+		return t.with(t.left, l), r
+	}
+	l, r := splitNodes(t.left, k)
+	return l, t.with(r, t.right)
+}
+
+// joinNodes joins a and b, all of whose nodes come before those of b.
+func joinNodes(a, b *inode) *inode {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.prio > b.prio:
+		return a.with(a.left, joinNodes(a.right, b))
+	}
+	return b.with(joinNodes(a, b.left), b.right)
+}
+
+func insertNode(t, n *inode) *inode {
+	if t == nil {
+		return n.fix()
+	}
+	if n.prio > t.prio {
+		l, r := splitNodes(t, n)
+		return n.with(l, r)
+	}
+	if n.before(t) {
+		return t.with(insertNode(t.left, n), t.right)
+	}
+	return t.with(t.left, insertNode(t.right, n))
+}
+
+func removeNode(t, n *inode) *inode {
+	switch {
+	case t == n:
+		return joinNodes(t.left, t.right)
+	case n.before(t):
+		return t.with(removeNode(t.left, n), t.right)
+	}
+	return t.with(t.left, removeNode(t.right, n))
+}
+
+// findNode returns the first node with a given start and end whose entry satisfies match.
+func findNode(t *inode, start, end endpoint, match func(interface{}) bool) *inode {
+	if t == nil {
+		return nil
+	}
+	switch {
+	case start.less(t.start) || start == t.start && end.less(t.end):
+		return findNode(t.left, start, end, match)
+	case t.start.less(start) || t.end.less(end):
+		return findNode(t.right, start, end, match)
+	}
+	if n := findNode(t.left, start, end, match); n != nil {
+		return n
+	}
+	if match(t.entry) {
+		return t
+	}
+	return findNode(t.right, start, end, match)
+}
+
+// stabNodes visits, in index order, the non-empty nodes that contain x. It returns false if
+// fn stopped the visit.
+func stabNodes(t *inode, x endpoint, fn func(interface{}) bool) bool {
+	if t == nil || !x.less(t.maxEnd) {
+		return true // no node in this subtree ends after x
+	}
+	if !stabNodes(t.left, x, fn) {
+		return false
+	}
+	if x.less(t.start) {
+		return true // this node and all on its right start after x
+	}
+	if x.less(t.end) && !fn(t.entry) {
+		return false
+	}
+	return stabNodes(t.right, x, fn)
+}
+
+// overlapNodes visits, in index order, the non-empty nodes that overlap the non-empty
+// interval from start to end. It returns false if fn stopped the visit.
+func overlapNodes(t *inode, start, end endpoint, fn func(interface{}) bool) bool {
+	if t == nil || !start.less(t.maxEnd) {
+		return true
+	}
+	if !overlapNodes(t.left, start, end, fn) {
+		return false
+	}
+	if !t.start.less(end) {
+		return true
+	}
+	if start.less(t.end) && t.start.less(t.end) && !fn(t.entry) {
+		return false
+	}
+	return overlapNodes(t.right, start, end, fn)
+}
+
+func walkNodes(t *inode, fn func(interface{})) {
+	if t != nil {
+		walkNodes(t.left, fn)
+		fn(t.entry)
+		walkNodes(t.right, fn)
+	}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// DateRangeEntry is a date range with an associated value, as held in a DateRangeIndex.
+type DateRangeEntry struct {
+	Range DateRange
+	Value interface{}
+}
+
+// DateRangeIndex is an interval tree of date ranges, each with an arbitrary value, that
+// quickly finds the ranges containing a date or overlapping another range. A query takes
+// O(log n + k) time for typical data, where k is the number of results, and at worst
+// O((k+1) log n); inserting and deleting take O(log n) time. These are expected times,
+// because the tree is balanced randomly. Deleting also takes time proportional to the
+// number of entries that have the same range.
+//
+// DateRangeIndex values are immutable: Insert and Delete return a new index, sharing most
+// of its structure with the original. So an index can be used concurrently by any number
+// of goroutines without locking, and a writer can publish updated indexes (e.g. via
+// sync/atomic.Value) without disturbing readers. The zero value is an empty index.
+//
+// As with Contains and Overlaps, empty date ranges can be held but are never found by
+// queries.
+type DateRangeIndex struct {
+	root *inode
+	seq  uint64
+}
+
+// NewDateRangeIndex builds an index holding some entries. This is faster than inserting
+// the entries one by one, taking O(n log n) time.
+func NewDateRangeIndex(entries ...DateRangeEntry) DateRangeIndex {
+	nodes := make([]inode, len(entries))
+	for i, e := range entries {
+		nodes[i] = newDateRangeNode(e, uint64(i))
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].before(&nodes[j])
+	})
+	return DateRangeIndex{buildNodes(nodes), uint64(len(entries))}
+}
+
+func newDateRangeNode(e DateRangeEntry, seq uint64) inode {
+	start, end := datePoint(e.Range.Start()), datePoint(e.Range.End())
+	return inode{start: start, end: end, seq: seq, prio: priority(seq), entry: e}
+}
+
+// Len returns the number of entries in the index.
+func (idx DateRangeIndex) Len() int {
+	return sizeOf(idx.root)
+}
+
+// Insert returns an index that also holds a date range with its value. Duplicates are
+// allowed.
+func (idx DateRangeIndex) Insert(dr DateRange, value interface{}) DateRangeIndex {
+	n := newDateRangeNode(DateRangeEntry{dr, value}, idx.seq)
+	return DateRangeIndex{insertNode(idx.root, &n), idx.seq + 1}
+}
+
+// Delete returns an index without one entry that has the same start and end as a date
+// range and a value equal to the given value. The values are compared using ==, so they
+// must be comparable. If there is no such entry, the index is returned unchanged and ok
+// is false.
+func (idx DateRangeIndex) Delete(dr DateRange, value interface{}) (result DateRangeIndex, ok bool) {
+	n := findNode(idx.root, datePoint(dr.Start()), datePoint(dr.End()), func(e interface{}) bool {
+		return e.(DateRangeEntry).Value == value
+	})
+	if n == nil {
+		return idx, false
+	}
+	return DateRangeIndex{removeNode(idx.root, n), idx.seq}, true
+}
+
+// Containing returns the entries whose ranges contain a date, ordered by their start dates.
+func (idx DateRangeIndex) Containing(d date.Date) []DateRangeEntry {
+	var result []DateRangeEntry
+	idx.VisitContaining(d, func(e DateRangeEntry) bool {
+		result = append(result, e)
+		return true
+	})
+	return result
+}
+
+// VisitContaining calls fn for each entry whose range contains a date, ordered by their
+// start dates, until fn returns false.
+// Unlike Containing, this does not build a slice of results.
+func (idx DateRangeIndex) VisitContaining(d date.Date, fn func(DateRangeEntry) bool) {
+	stabNodes(idx.root, datePoint(d), func(e interface{}) bool {
+		return fn(e.(DateRangeEntry))
+	})
+}
+
+// Overlapping returns the entries whose ranges overlap a date range (see
+// DateRange.Overlaps), ordered by their start dates.
+func (idx DateRangeIndex) Overlapping(dr DateRange) []DateRangeEntry {
+	var result []DateRangeEntry
+	idx.VisitOverlapping(dr, func(e DateRangeEntry) bool {
+		result = append(result, e)
+		return true
+	})
+	return result
+}
+
+// VisitOverlapping calls fn for each entry whose range overlaps a date range, ordered by
+// their start dates, until fn returns false.
+// Unlike Overlapping, this does not build a slice of results.
+func (idx DateRangeIndex) VisitOverlapping(dr DateRange, fn func(DateRangeEntry) bool) {
+	if dr.days == 0 {
+		return
+	}
+	overlapNodes(idx.root, datePoint(dr.Start()), datePoint(dr.End()), func(e interface{}) bool {
+		return fn(e.(DateRangeEntry))
+	})
+}
+
+// Entries returns all the entries in the index, ordered by their start dates, then their
+// end dates, then the order in which they were added.
+func (idx DateRangeIndex) Entries() []DateRangeEntry {
+	result := make([]DateRangeEntry, 0, idx.Len())
+	walkNodes(idx.root, func(e interface{}) {
+		result = append(result, e.(DateRangeEntry))
+	})
+	return result
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// TimeSpanEntry is a time span with an associated value, as held in a TimeSpanIndex.
+type TimeSpanEntry struct {
+	Span  TimeSpan
+	Value interface{}
+}
+
+// TimeSpanIndex is an interval tree of time spans, each with an arbitrary value, that
+// quickly finds the spans containing an instant or overlapping another span. It is
+// otherwise the same as DateRangeIndex; in particular, it is immutable and so is safe
+// for concurrent use. The zero value is an empty index.
+//
+// As with Contains and Overlaps, empty time spans can be held but are never found by
+// queries.
+type TimeSpanIndex struct {
+	root *inode
+	seq  uint64
+}
+
+// NewTimeSpanIndex builds an index holding some entries. This is faster than inserting
+// the entries one by one, taking O(n log n) time.
+func NewTimeSpanIndex(entries ...TimeSpanEntry) TimeSpanIndex {
+	nodes := make([]inode, len(entries))
+	for i, e := range entries {
+		nodes[i] = newTimeSpanNode(e, uint64(i))
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].before(&nodes[j])
+	})
+	return TimeSpanIndex{buildNodes(nodes), uint64(len(entries))}
+}
+
+func newTimeSpanNode(e TimeSpanEntry, seq uint64) inode {
+	start, end := timePoint(e.Span.Start()), timePoint(e.Span.End())
+	return inode{start: start, end: end, seq: seq, prio: priority(seq), entry: e}
+}
+
+// Len returns the number of entries in the index.
+func (idx TimeSpanIndex) Len() int {
+	return sizeOf(idx.root)
+}
+
+// Insert returns an index that also holds a time span with its value. Duplicates are
+// allowed.
+func (idx TimeSpanIndex) Insert(ts TimeSpan, value interface{}) TimeSpanIndex {
+	n := newTimeSpanNode(TimeSpanEntry{ts, value}, idx.seq)
+	return TimeSpanIndex{insertNode(idx.root, &n), idx.seq + 1}
+}
+
+// Delete returns an index without one entry that has the same start and end instants as
+// a time span and a value equal to the given value. The values are compared using ==, so
+// they must be comparable. If there is no such entry, the index is returned unchanged and
+// ok is false.
+func (idx TimeSpanIndex) Delete(ts TimeSpan, value interface{}) (result TimeSpanIndex, ok bool) {
+	n := findNode(idx.root, timePoint(ts.Start()), timePoint(ts.End()), func(e interface{}) bool {
+		return e.(TimeSpanEntry).Value == value
+	})
+	if n == nil {
+		return idx, false
+	}
+	return TimeSpanIndex{removeNode(idx.root, n), idx.seq}, true
+}
+
+// Containing returns the entries whose spans contain an instant, ordered by their start
+// times.
+func (idx TimeSpanIndex) Containing(t time.Time) []TimeSpanEntry {
+	var result []TimeSpanEntry
+	idx.VisitContaining(t, func(e TimeSpanEntry) bool {
+		result = append(result, e)
+		return true
+	})
+	return result
+}
+
+// VisitContaining calls fn for each entry whose span contains an instant, ordered by
+// their start times, until fn returns false.
+// Unlike Containing, this does not build a slice of results.
+func (idx TimeSpanIndex) VisitContaining(t time.Time, fn func(TimeSpanEntry) bool) {
+	stabNodes(idx.root, timePoint(t), func(e interface{}) bool {
+		return fn(e.(TimeSpanEntry))
+	})
+}
+
+// Overlapping returns the entries whose spans overlap a time span (see TimeSpan.Overlaps),
+// ordered by their start times.
+func (idx TimeSpanIndex) Overlapping(ts TimeSpan) []TimeSpanEntry {
+	var result []TimeSpanEntry
+	idx.VisitOverlapping(ts, func(e TimeSpanEntry) bool {
+		result = append(result, e)
+		return true
+	})
+	return result
+}
+
+// VisitOverlapping calls fn for each entry whose span overlaps a time span, ordered by
+// their start times, until fn returns false.
+// Unlike Overlapping, this does not build a slice of results.
+func (idx TimeSpanIndex) VisitOverlapping(ts TimeSpan, fn func(TimeSpanEntry) bool) {
+	if ts.duration == 0 {
+		return
+	}
+	overlapNodes(idx.root, timePoint(ts.Start()), timePoint(ts.End()), func(e interface{}) bool {
+		return fn(e.(TimeSpanEntry))
+	})
+}
+
+// Entries returns all the entries in the index, ordered by their start times, then their
+// end times, then the order in which they were added.
+func (idx TimeSpanIndex) Entries() []TimeSpanEntry {
+	result := make([]TimeSpanEntry, 0, idx.Len())
+	walkNodes(idx.root, func(e interface{}) {
+		result = append(result, e.(TimeSpanEntry))
+	})
+	return result
+}
@@ -0,0 +1,301 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simplylizz/date"
+)
+
+func TestDateRangeIndexQueries(t *testing.T) {
+	idx := NewDateRangeIndex(
+		DateRangeEntry{NewDateRange(d0325, d0401), "b"},
+		DateRangeEntry{NewDateRange(d0320, d0326), "a"},
+		DateRangeEntry{DateRange{d0330, -3}, "c"},
+		DateRangeEntry{EmptyRange(d0327), "empty"},
+		DateRangeEntry{NewDateRange(d0401, d0410), "d"},
+	)
+	isEq(t, 0, idx.Len(), 5)
+
+	cases := []struct {
+		d    date.Date
+		want []string
+	}{
+		{d0320, []string{"a"}},
+		{d0325, []string{"a", "b"}},
+		{d0326, []string{"b"}},
+		{d0327, []string{"b"}},
+		{d0328, []string{"b", "c"}},
+		{d0331, []string{"b"}},
+		{d0401, []string{"d"}},
+		{d0410, nil},
+	}
+	for i, c := range cases {
+		isEq(t, i, values(idx.Containing(c.d)), join(c.want), c.d)
+	}
+
+	isEq(t, 0, values(idx.Overlapping(NewDateRange(d0326, d0329))), "b c")
+	isEq(t, 0, values(idx.Overlapping(NewDateRange(d0321, d0402))), "a b c d")
+	isEq(t, 0, values(idx.Overlapping(NewDateRange(d0410, d0501))), "")
+	isEq(t, 0, values(idx.Overlapping(EmptyRange(d0327))), "")
+	isEq(t, 0, values(idx.Overlapping(DateRange{d0320, -1})), "a")
+	isEq(t, 0, values(idx.Overlapping(DateRange{d0325, -5})), "a b")
+
+	var visited []DateRangeEntry
+	idx.VisitContaining(d0325, func(e DateRangeEntry) bool {
+		visited = append(visited, e)
+		return false
+	})
+	isEq(t, 0, values(visited), "a")
+
+	isEq(t, 0, values(idx.Entries()), "a b empty c d")
+	isEq(t, 0, values(DateRangeIndex{}.Containing(d0320)), "")
+	isEq(t, 0, DateRangeIndex{}.Len(), 0)
+}
+
+func TestDateRangeIndexInsertAndDelete(t *testing.T) {
+	var idx DateRangeIndex
+	idx = idx.Insert(NewDateRange(d0325, d0401), "x")
+	idx2 := idx.Insert(NewDateRange(d0325, d0401), "y").Insert(NewDateRange(d0320, d0330), "z")
+
+	isEq(t, 0, idx.Len(), 1)
+	isEq(t, 0, idx2.Len(), 3)
+	isEq(t, 0, values(idx2.Containing(d0327)), "z x y")
+
+	idx3, ok := idx2.Delete(DateRange{d0331, -7}, "x")
+	isEq(t, 0, ok, true)
+	isEq(t, 0, values(idx3.Containing(d0327)), "z y")
+	isEq(t, 0, values(idx2.Containing(d0327)), "z x y") // unchanged
+
+	_, ok = idx3.Delete(NewDateRange(d0325, d0401), "x")
+	isEq(t, 0, ok, false)
+	_, ok = idx3.Delete(NewDateRange(d0325, d0331), "y")
+	isEq(t, 0, ok, false)
+}
+
+func TestDateRangeIndexAgainstLinearScan(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randomRange := func() DateRange {
+		return DayRange(d0320.Add(date.PeriodOfDays(rnd.Intn(365))), date.PeriodOfDays(rnd.Intn(60)))
+	}
+
+	var entries []DateRangeEntry
+	for i := 0; i < 500; i++ {
+		entries = append(entries, DateRangeEntry{randomRange(), i})
+	}
+	idx := NewDateRangeIndex(entries[:300]...)
+	for _, e := range entries[300:] {
+		idx = idx.Insert(e.Range, e.Value)
+	}
+	for i := 0; i < len(entries); i += 3 {
+		var ok bool
+		idx, ok = idx.Delete(entries[i].Range, entries[i].Value)
+		isEq(t, i, ok, true)
+	}
+
+	var kept []DateRangeEntry
+	for i, e := range entries {
+		if i%3 != 0 {
+			kept = append(kept, e)
+		}
+	}
+	isEq(t, 0, idx.Len(), len(kept))
+
+	for i := 0; i < 200; i++ {
+		d := d0320.Add(date.PeriodOfDays(rnd.Intn(400) - 10))
+		want := map[interface{}]bool{}
+		for _, e := range kept {
+			if e.Range.Contains(d) {
+				want[e.Value] = true
+			}
+		}
+		got := idx.Containing(d)
+		isEq(t, i, len(got), len(want), d)
+		for _, e := range got {
+			isEq(t, i, want[e.Value], true, d, e)
+		}
+
+		q := randomRange()
+		want = map[interface{}]bool{}
+		for _, e := range kept {
+			if e.Range.Overlaps(q) {
+				want[e.Value] = true
+			}
+		}
+		got = idx.Overlapping(q)
+		isEq(t, i, len(got), len(want), q)
+		for j, e := range got {
+			isEq(t, i, want[e.Value], true, q, e)
+			if j > 0 {
+				isEq(t, i, got[j-1].Range.Start().After(e.Range.Start()), false, q)
+			}
+		}
+	}
+}
+
+func TestTimeSpanIndex(t *testing.T) {
+	h := time.Hour
+	idx := NewTimeSpanIndex(
+		TimeSpanEntry{NewTimeSpan(t0327, t0328), "a"},
+		TimeSpanEntry{TimeSpanOf(t0329, -30*h), "b"},
+		TimeSpanEntry{NewTimeSpan(t0329.In(london), t0330.In(london)), "c"},
+	)
+
+	isEq(t, 0, values(idx.Containing(t0327)), "a")
+	isEq(t, 0, values(idx.Containing(t0328.Add(-h))), "a b")
+	isEq(t, 0, values(idx.Containing(t0329)), "c")
+	isEq(t, 0, values(idx.Containing(t0330)), "")
+	isEq(t, 0, values(idx.Overlapping(NewTimeSpan(t0328, t0329.Add(h)))), "b c")
+	isEq(t, 0, values(idx.Overlapping(ZeroTimeSpan(t0328))), "")
+
+	idx2, ok := idx.Delete(NewTimeSpan(t0329, t0330), "c")
+	isEq(t, 0, ok, true)
+	isEq(t, 0, values(idx2.Entries()), "a b")
+	isEq(t, 0, values(idx2.Insert(NewTimeSpan(t0327, t0330), "d").Containing(t0329)), "d")
+	isEq(t, 0, idx.Len(), 3)
+
+	// instants far outside the range of UnixNano
+	ancient := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	idx3 := idx.Insert(NewTimeSpan(ancient, t0327), "e")
+	isEq(t, 0, values(idx3.Containing(ancient.Add(h))), "e")
+	isEq(t, 0, values(idx3.Containing(t0327)), "a")
+}
+
+func values(entries interface{}) string {
+	var s []string
+	switch es := entries.(type) {
+	case []DateRangeEntry:
+		for _, e := range es {
+			s = append(s, e.Value.(string))
+		}
+	case []TimeSpanEntry:
+		for _, e := range es {
+			s = append(s, e.Value.(string))
+		}
+	}
+	return join(s)
+}
+
+func join(s []string) string {
+	return strings.Join(s, " ")
+}
+
+//-------------------------------------------------------------------------------------------------
+
+const benchmarkSize = 200000
+
+// benchmarkEntries makes date ranges of up to maxDays, starting at random in a thirty-year
+// period, like contracts or price validity records.
+func benchmarkEntries(n, maxDays int) []DateRangeEntry {
+	rnd := rand.New(rand.NewSource(1))
+	entries := make([]DateRangeEntry, n)
+	start := date.New(2000, time.January, 1)
+	for i := range entries {
+		d := start.Add(date.PeriodOfDays(rnd.Intn(30 * 365)))
+		entries[i] = DateRangeEntry{DayRange(d, date.PeriodOfDays(1+rnd.Intn(maxDays))), i}
+	}
+	return entries
+}
+
+func benchmarkDates(n int) []date.Date {
+	rnd := rand.New(rand.NewSource(2))
+	dates := make([]date.Date, n)
+	start := date.New(2000, time.January, 1)
+	for i := range dates {
+		dates[i] = start.Add(date.PeriodOfDays(rnd.Intn(30 * 365)))
+	}
+	return dates
+}
+
+// benchmarkRangeLengths gives the maximum lengths of the ranges in the queries benchmarks;
+// with 200000 ranges, a date is in about 270 short ones or 6700 long ones.
+var benchmarkRangeLengths = []struct {
+	name    string
+	maxDays int
+}{
+	{"short", 30},
+	{"long", 730},
+}
+
+func BenchmarkDateRangeIndexBuild(b *testing.B) {
+	entries := benchmarkEntries(benchmarkSize, 30)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		NewDateRangeIndex(entries...)
+	}
+}
+
+func BenchmarkDateRangeIndexInsert(b *testing.B) {
+	entries := benchmarkEntries(benchmarkSize+1024, 30)
+	idx := NewDateRangeIndex(entries[:benchmarkSize]...)
+	extra := entries[benchmarkSize:]
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		e := extra[n%len(extra)]
+		idx.Insert(e.Range, e.Value)
+	}
+}
+
+func BenchmarkDateRangeIndexDelete(b *testing.B) {
+	entries := benchmarkEntries(benchmarkSize, 30)
+	idx := NewDateRangeIndex(entries...)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		e := entries[n%len(entries)]
+		if _, ok := idx.Delete(e.Range, e.Value); !ok {
+			b.Fatalf("%v not deleted", e)
+		}
+	}
+}
+
+func BenchmarkDateRangeIndexContaining(b *testing.B) {
+	for _, bl := range benchmarkRangeLengths {
+		b.Run(bl.name, func(b *testing.B) {
+			idx := NewDateRangeIndex(benchmarkEntries(benchmarkSize, bl.maxDays)...)
+			dates := benchmarkDates(1024)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				idx.VisitContaining(dates[n%len(dates)], func(DateRangeEntry) bool { return true })
+			}
+		})
+	}
+}
+
+func BenchmarkDateRangeIndexOverlapping(b *testing.B) {
+	for _, bl := range benchmarkRangeLengths {
+		b.Run(bl.name, func(b *testing.B) {
+			idx := NewDateRangeIndex(benchmarkEntries(benchmarkSize, bl.maxDays)...)
+			dates := benchmarkDates(1024)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				idx.VisitOverlapping(DayRange(dates[n%len(dates)], 7), func(DateRangeEntry) bool { return true })
+			}
+		})
+	}
+}
+
+// BenchmarkLinearScanContaining is the baseline against which to compare
+// BenchmarkDateRangeIndexContaining.
+func BenchmarkLinearScanContaining(b *testing.B) {
+	for _, bl := range benchmarkRangeLengths {
+		b.Run(bl.name, func(b *testing.B) {
+			entries := benchmarkEntries(benchmarkSize, bl.maxDays)
+			dates := benchmarkDates(1024)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				d := dates[n%len(dates)]
+				for _, e := range entries {
+					if e.Range.Contains(d) {
+						_ = e.Value
+					}
+				}
+			}
+		})
+	}
+}
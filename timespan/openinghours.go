@@ -0,0 +1,506 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simplylizz/date"
+	"github.com/simplylizz/date/clock"
+)
+
+// OpeningHours is a weekly schedule of opening times, such as for a shop or a support desk.
+// Each weekday has a list of time-of-day ranges during which it is open. A range can wrap
+// past midnight (e.g. Friday 22:00-02:00), in which case it runs into the following day.
+//
+// The weekly schedule can be overridden for specific dates, such as for special hours, and
+// for public holidays. The dates of the public holidays are not known in advance, so they
+// must be added using AddPublicHolidays.
+//
+// All the clock times are wall-clock times in the schedule's location, so the opening
+// hours follow any daylight-saving changes.
+type OpeningHours struct {
+	weekly     [7][]clock.Range
+	exceptions map[date.Date][]clock.Range
+	holidays   map[date.Date]bool
+	ph         []clock.Range
+	hasPH      bool
+	loc        *time.Location
+}
+
+// NewOpeningHours creates an empty schedule, i.e. one that is always closed, in the
+// specified location. If loc is nil, UTC is used.
+func NewOpeningHours(loc *time.Location) *OpeningHours {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &OpeningHours{
+		exceptions: make(map[date.Date][]clock.Range),
+		holidays:   make(map[date.Date]bool),
+		loc:        loc,
+	}
+}
+
+// Location returns the location of the schedule.
+func (oh *OpeningHours) Location() *time.Location {
+	return oh.loc
+}
+
+// SetWeekday sets the opening hours for a day of the week, replacing any previous ones.
+// With no ranges, the day is closed.
+func (oh *OpeningHours) SetWeekday(day time.Weekday, ranges ...clock.Range) {
+	oh.weekly[day] = nonEmpty(ranges)
+}
+
+// SetDate sets the opening hours for a specific date, overriding the weekly schedule and
+// any public holiday hours. With no ranges, the date is closed.
+func (oh *OpeningHours) SetDate(d date.Date, ranges ...clock.Range) {
+	oh.exceptions[d] = nonEmpty(ranges)
+}
+
+// SetPublicHolidayHours sets the opening hours for public holidays, overriding the weekly
+// schedule. With no ranges, public holidays are closed.
+func (oh *OpeningHours) SetPublicHolidayHours(ranges ...clock.Range) {
+	oh.ph = nonEmpty(ranges)
+	oh.hasPH = true
+}
+
+// AddPublicHolidays marks dates as public holidays. These have the hours set by
+// SetPublicHolidayHours; if that has not been used, the weekly schedule applies.
+func (oh *OpeningHours) AddPublicHolidays(dates ...date.Date) {
+	for _, d := range dates {
+		oh.holidays[d] = true
+	}
+}
+
+func nonEmpty(ranges []clock.Range) []clock.Range {
+	var result []clock.Range
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// HoursOn returns the opening hours that start on a given date. Any that wrap past
+// midnight continue into the following date.
+func (oh *OpeningHours) HoursOn(d date.Date) []clock.Range {
+	if ranges, exists := oh.exceptions[d]; exists {
+		return ranges
+	}
+	if oh.hasPH && oh.holidays[d] {
+		return oh.ph
+	}
+	return oh.weekly[d.Weekday()]
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// IsOpenAt tests whether the schedule is open at a given instant.
+func (oh *OpeningHours) IsOpenAt(t time.Time) bool {
+	d := date.NewAt(t.In(oh.loc))
+	for _, ts := range oh.spans(d.Add(-1), d.Add(1)) {
+		if ts.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpen returns the first instant at or after t when the schedule is open. So if it is
+// already open at t, the result is t. The result is false if the schedule never opens again.
+func (oh *OpeningHours) NextOpen(t time.Time) (time.Time, bool) {
+	from, to := oh.horizon(t)
+	for _, ts := range oh.spans(from, to) {
+		if ts.Contains(t) {
+			return t, true
+		}
+		if ts.Start().After(t) {
+			return ts.Start(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// NextClose returns the first instant after t when the schedule closes. If it is closed
+// at t, this is the end of the next opening. The result is false if the schedule never
+// closes again (e.g. it is open 24/7) or never opens again.
+func (oh *OpeningHours) NextClose(t time.Time) (time.Time, bool) {
+	from, to := oh.horizon(t)
+	end := at(to, clock.Midnight, oh.loc)
+	for _, ts := range oh.spans(from, to) {
+		if ts.End().After(t) {
+			if !ts.End().Before(end) {
+				return time.Time{}, false // still open at the horizon
+			}
+			return ts.End(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// horizon finds the dates within which the next opening and closing times must lie. After
+// the last exception or holiday, the weekly schedule repeats, so one more week suffices;
+// the extra day allows for a range that wraps past midnight.
+func (oh *OpeningHours) horizon(t time.Time) (date.Date, date.Date) {
+	d := date.NewAt(t.In(oh.loc))
+	last := d
+	for x := range oh.exceptions {
+		last = last.Max(x)
+	}
+	for x := range oh.holidays {
+		last = last.Max(x)
+	}
+	return d.Add(-1), last.Add(9)
+}
+
+// OpenSpans returns the times when the schedule is open during a date range, in the
+// schedule's location. Opening hours that abut one another, such as one day's hours
+// ending at midnight and the next day's starting at midnight, are merged together.
+// The results are clipped to the date range and ordered by time.
+func (oh *OpeningHours) OpenSpans(dr DateRange) []TimeSpan {
+	dr = dr.Normalise()
+	start, end := dr.StartTimeIn(oh.loc), dr.EndTimeIn(oh.loc)
+
+	var result []TimeSpan
+	for _, ts := range oh.spans(dr.Start().Add(-1), dr.End()) {
+		s, e := ts.Start(), ts.End()
+		if s.Before(start) {
+			s = start
+		}
+		if e.After(end) {
+			e = end
+		}
+		if s.Before(e) {
+			result = append(result, NewTimeSpan(s, e))
+		}
+	}
+	return result
+}
+
+// spans gets the merged opening times for the hours starting on the dates from 'from'
+// up to but excluding 'to'.
+func (oh *OpeningHours) spans(from, to date.Date) []TimeSpan {
+	var list []TimeSpan
+	for d := from; d.Before(to); d = d.Add(1) {
+		for _, r := range oh.HoursOn(d) {
+			end := d
+			if r.Wraps() {
+				end = d.Add(1)
+			}
+			list = append(list, NewTimeSpan(at(d, r.Start(), oh.loc), at(end, r.End(), oh.loc)))
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Start().Before(list[j].Start())
+	})
+
+	var merged []TimeSpan
+	for _, ts := range list {
+		n := len(merged)
+		if n > 0 && !ts.Start().After(merged[n-1].End()) {
+			if ts.End().After(merged[n-1].End()) {
+				merged[n-1] = NewTimeSpan(merged[n-1].Start(), ts.End())
+			}
+		} else {
+			merged = append(merged, ts)
+		}
+	}
+	return merged
+}
+
+// at gets the instant of a wall-clock time on a given date.
+func at(d date.Date, c clock.Clock, loc *time.Location) time.Time {
+	if c == clock.Day {
+		d, c = d.Add(1), clock.Midnight
+	}
+	y, m, day := d.Date()
+	return time.Date(y, m, day, c.Hours(), c.Minutes(), c.Seconds(), c.Millisec()*int(time.Millisecond), loc)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+var osmDays = []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+var osmMonths = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+// String gets the schedule in the OpenStreetMap opening_hours syntax, e.g.
+// "Mo-Fr 09:00-17:00; Sa 10:00-14:00; PH off". Consecutive days with the same hours
+// are grouped together. A schedule that is always closed is "off".
+func (oh *OpeningHours) String() string {
+	var rules []string
+
+	// the week starts on Monday in the OSM syntax
+	for i := 1; i <= 7; {
+		day := i % 7
+		j := i + 1
+		for j <= 7 && equalRanges(oh.weekly[j%7], oh.weekly[day]) {
+			j++
+		}
+		if len(oh.weekly[day]) > 0 {
+			sel := osmDays[day]
+			if j-i > 2 {
+				sel += "-" + osmDays[(j-1)%7]
+			} else if j-i == 2 {
+				sel += "," + osmDays[(j-1)%7]
+			}
+			rules = append(rules, sel+" "+formatRanges(oh.weekly[day]))
+		}
+		i = j
+	}
+
+	if oh.hasPH {
+		rules = append(rules, "PH "+formatRanges(oh.ph))
+	}
+
+	var dates []date.Date
+	for d := range oh.exceptions {
+		dates = append(dates, d)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	for _, d := range dates {
+		y, m, day := d.Date()
+		rules = append(rules, fmt.Sprintf("%d %s %02d %s", y, osmMonths[m-1], day, formatRanges(oh.exceptions[d])))
+	}
+
+	if len(rules) == 0 {
+		return "off"
+	}
+	return strings.Join(rules, "; ")
+}
+
+func equalRanges(a, b []clock.Range) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func formatRanges(ranges []clock.Range) string {
+	if len(ranges) == 0 {
+		return "off"
+	}
+	s := make([]string, len(ranges))
+	for i, r := range ranges {
+		s[i] = r.String()
+	}
+	return strings.Join(s, ",")
+}
+
+// MustParseOpeningHours is as per ParseOpeningHours except that it panics if the string
+// cannot be parsed. This is intended for setup code; don't use it for user inputs.
+func MustParseOpeningHours(text string, loc *time.Location) *OpeningHours {
+	oh, err := ParseOpeningHours(text, loc)
+	if err != nil {
+		panic(err)
+	}
+	return oh
+}
+
+// ParseOpeningHours parses a schedule written in (a subset of) the OpenStreetMap
+// opening_hours syntax, e.g. "Mo-Fr 09:00-17:00; Sa 10:00-14:00; PH off".
+// See https://wiki.openstreetmap.org/wiki/Key:opening_hours
+//
+// The rules are separated by semicolons. Each rule has an optional selector followed by
+// comma-separated time ranges, or "off" (or "closed"). The selector is one of
+//
+//   - weekdays, e.g. "Mo", "Mo-Fr", "Mo,We,Fr" or "Fr-Mo";
+//   - "PH" for public holidays (see AddPublicHolidays);
+//   - a specific date, e.g. "2024 Dec 25".
+//
+// A rule without a selector applies to every day of the week, and "24/7" means always open.
+// Later rules replace earlier ones for the same days. Time ranges are "hh:mm-hh:mm"; the end
+// can be after midnight, e.g. "22:00-02:00" or "22:00-26:00".
+func ParseOpeningHours(text string, loc *time.Location) (*OpeningHours, error) {
+	oh := NewOpeningHours(loc)
+
+	for _, rule := range strings.Split(text, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		if rule == "24/7" {
+			for day := range oh.weekly {
+				oh.weekly[day] = []clock.Range{clock.NewRange(clock.Midnight, clock.Day)}
+			}
+			continue
+		}
+
+		selector, hours := splitOSMRule(strings.Fields(rule))
+
+		ranges, err := parseOSMRanges(hours)
+		if err != nil {
+			return nil, fmt.Errorf("OpeningHours: cannot parse %q: %v", rule, err)
+		}
+
+		switch {
+		case len(selector) == 0:
+			for day := range oh.weekly {
+				oh.weekly[day] = ranges
+			}
+
+		case len(selector) == 1 && selector[0] == "PH":
+			oh.SetPublicHolidayHours(ranges...)
+
+		case len(selector) == 1:
+			days, err := parseOSMDays(selector[0])
+			if err != nil {
+				return nil, fmt.Errorf("OpeningHours: cannot parse %q: %v", rule, err)
+			}
+			for _, day := range days {
+				oh.weekly[day] = ranges
+			}
+
+		case len(selector) == 3:
+			d, err := parseOSMDate(selector)
+			if err != nil {
+				return nil, fmt.Errorf("OpeningHours: cannot parse %q: %v", rule, err)
+			}
+			oh.SetDate(d, ranges...)
+
+		default:
+			return nil, fmt.Errorf("OpeningHours: cannot parse %q: unsupported selector", rule)
+		}
+	}
+
+	return oh, nil
+}
+
+// splitOSMRule separates the selector from the hours, which are the remaining fields
+// joined together, e.g. "09:00-12:00," and "13:00-17:00".
+func splitOSMRule(fields []string) (selector []string, hours string) {
+	n := 1
+	switch {
+	case len(fields) > 3 && len(fields[0]) == 4 && isDigits(fields[0]):
+		n = 3 // a date, e.g. 2024 Dec 25
+	case fields[0] == "off" || fields[0] == "closed" || isDigits(fields[0][:1]):
+		n = 0
+	case len(fields) == 1:
+		return fields, ""
+	}
+	return fields[:n], strings.Join(fields[n:], "")
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func parseOSMRanges(hours string) ([]clock.Range, error) {
+	if hours == "off" || hours == "closed" {
+		return nil, nil
+	}
+
+	var ranges []clock.Range
+	for _, s := range strings.Split(hours, ",") {
+		hyphen := strings.IndexByte(s, '-')
+		if hyphen < 0 {
+			return nil, fmt.Errorf("invalid time range %q", s)
+		}
+		// the 24-hour layout allows times past midnight, such as 26:00
+		start, err := clock.ParseLayout("15:04", s[:hyphen])
+		if err != nil {
+			return nil, err
+		}
+		end, err := clock.ParseLayout("15:04", s[hyphen+1:])
+		if err != nil {
+			return nil, err
+		}
+		if end < start {
+			end += clock.Day
+		}
+		if !start.IsInOneDay() || start == clock.Day || end-start > clock.Day {
+			return nil, fmt.Errorf("invalid time range %q", s)
+		}
+		// a whole day can only be expressed as 00:00-24:00 (see clock.NewRange)
+		r := clock.NewRange(start, end)
+		if r.IsEmpty() {
+			return nil, fmt.Errorf("empty or 24-hour time range %q", s)
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+func parseOSMDays(selector string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, s := range strings.Split(selector, ",") {
+		parts := strings.Split(s, "-")
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("invalid weekdays %q", s)
+		}
+
+		first, err := parseOSMDay(parts[0])
+		if err != nil {
+			return nil, err
+		}
+
+		last := first
+		if len(parts) == 2 {
+			last, err = parseOSMDay(parts[1])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// ranges can wrap round the end of the week, e.g. Fr-Mo
+		for day := first; ; day = (day + 1) % 7 {
+			days = append(days, day)
+			if day == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func parseOSMDay(s string) (time.Weekday, error) {
+	for i, name := range osmDays {
+		if s == name {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
+
+func parseOSMDate(fields []string) (date.Date, error) {
+	year, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return date.Date{}, fmt.Errorf("invalid year %q", fields[0])
+	}
+
+	month := 0
+	for i, name := range osmMonths {
+		if fields[1] == name {
+			month = i + 1
+		}
+	}
+	if month == 0 {
+		return date.Date{}, fmt.Errorf("invalid month %q", fields[1])
+	}
+
+	day, err := strconv.Atoi(fields[2])
+	if err != nil || day < 1 || day > date.DaysIn(year, time.Month(month)) {
+		return date.Date{}, fmt.Errorf("invalid day %q", fields[2])
+	}
+
+	return date.New(year, time.Month(month), day), nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/simplylizz/date/clock"
+)
+
+// 2015-03-27 is a Friday; the clocks go forward in the UK on Sunday 2015-03-29
+func lt(day, hour, min int) time.Time {
+	return time.Date(2015, time.March, day, hour, min, 0, 0, london)
+}
+
+func TestParseOpeningHours(t *testing.T) {
+	cases := []struct {
+		text, want string
+	}{
+		{"Mo-Fr 09:00-17:00; Sa 10:00-14:00; PH off", "Mo-Fr 09:00-17:00; Sa 10:00-14:00; PH off"},
+		{"Mo-Fr 09:00-12:00, 13:00-17:00", "Mo-Fr 09:00-12:00,13:00-17:00"},
+		{"10:00-18:00; Su off", "Mo-Sa 10:00-18:00"},
+		{"Mo-Fr 09:00-17:00; We 10:00-12:00", "Mo,Tu 09:00-17:00; We 10:00-12:00; Th,Fr 09:00-17:00"},
+		{"Fr-Mo 22:00-02:00", "Mo 22:00-02:00; Fr-Su 22:00-02:00"},
+		{"Sa 22:00-26:00", "Sa 22:00-02:00"},
+		{"Mo,We,Fr 08:00-24:00", "Mo 08:00-24:00; We 08:00-24:00; Fr 08:00-24:00"},
+		{"24/7", "Mo-Su 00:00-24:00"},
+		{"24/7; 2015 Dec 25 off", "Mo-Su 00:00-24:00; 2015 Dec 25 off"},
+		{"Mo-Fr 09:00-17:00; 2015 Dec 24 09:00-12:00", "Mo-Fr 09:00-17:00; 2015 Dec 24 09:00-12:00"},
+		{"PH 10:00-12:00", "PH 10:00-12:00"},
+		{"", "off"},
+		{"off", "off"},
+	}
+	for i, c := range cases {
+		oh, err := ParseOpeningHours(c.text, london)
+		if err != nil {
+			t.Errorf("%d: %q: %v", i, c.text, err)
+			continue
+		}
+		isEq(t, i, oh.String(), c.want, c.text)
+		isEq(t, i, MustParseOpeningHours(oh.String(), london).String(), c.want, c.text)
+	}
+}
+
+func TestParseOpeningHoursErrors(t *testing.T) {
+	cases := []string{
+		"Mo-Fr",
+		"Xx 09:00-17:00",
+		"Mo-Fr-Sa 09:00-17:00",
+		"Mo 09:00",
+		"Mo 9am-5pm",
+		"Mo 17:00-42:00",
+		"Mo 25:00-26:00",
+		"Mo-Su 08:00-32:00",
+		"Fr 20:00-20:00",
+		"Mo 00:00-00:00",
+		"2015 Foo 25 off",
+		"2015 Feb 30 off",
+		"Mo Tu 09:00-17:00",
+	}
+	for i, c := range cases {
+		if _, err := ParseOpeningHours(c, london); err == nil {
+			t.Errorf("%d: %q: expected error", i, c)
+		}
+	}
+}
+
+func TestOpeningHoursIsOpenAt(t *testing.T) {
+	oh := MustParseOpeningHours("Mo-Fr 09:00-17:00; Fr 09:00-17:00,22:00-02:00; Sa 10:00-14:00; PH off", london)
+	oh.AddPublicHolidays(d0330)
+
+	cases := []struct {
+		t    time.Time
+		want bool
+	}{
+		{lt(27, 8, 59), false},
+		{lt(27, 9, 0), true},
+		{lt(27, 16, 59), true},
+		{lt(27, 17, 0), false},
+		{lt(27, 23, 0), true},
+		{lt(28, 1, 59), true},
+		{lt(28, 2, 0), false},
+		{lt(28, 12, 0), true},
+		{lt(28, 12, 0).UTC(), true},
+		{lt(29, 12, 0), false},
+		{lt(30, 12, 0), false}, // public holiday
+		{lt(31, 12, 0), true},
+	}
+	for i, c := range cases {
+		isEq(t, i, oh.IsOpenAt(c.t), c.want, c.t)
+	}
+}
+
+func TestOpeningHoursNextOpenAndClose(t *testing.T) {
+	oh := MustParseOpeningHours("Mo-Fr 09:00-17:00; Fr 09:00-17:00,22:00-02:00; PH off", london)
+	oh.AddPublicHolidays(d0330)
+
+	cases := []struct {
+		t               time.Time
+		nextOpen, close time.Time
+	}{
+		{lt(27, 8, 0), lt(27, 9, 0), lt(27, 17, 0)},
+		{lt(27, 10, 0), lt(27, 10, 0), lt(27, 17, 0)},
+		{lt(27, 17, 0), lt(27, 22, 0), time.Date(2015, time.March, 28, 2, 0, 0, 0, london)},
+		{lt(28, 3, 0), lt(31, 9, 0), lt(31, 17, 0)},
+	}
+	for i, c := range cases {
+		o, ok := oh.NextOpen(c.t)
+		isEq(t, i, ok, true)
+		isEq(t, i, o.Equal(c.nextOpen), true, o, c.nextOpen)
+		e, ok := oh.NextClose(c.t)
+		isEq(t, i, ok, true)
+		isEq(t, i, e.Equal(c.close), true, e, c.close)
+	}
+
+	always := MustParseOpeningHours("24/7", london)
+	_, ok := always.NextClose(lt(27, 12, 0))
+	isEq(t, 0, ok, false)
+
+	never := MustParseOpeningHours("off", london)
+	_, ok = never.NextOpen(lt(27, 12, 0))
+	isEq(t, 0, ok, false)
+
+	special := NewOpeningHours(london)
+	special.SetDate(d0501, clock.NewRange(clock.New(10, 0, 0, 0), clock.New(12, 0, 0, 0)))
+	o, ok := special.NextOpen(lt(27, 12, 0))
+	isEq(t, 0, ok, true)
+	isEq(t, 0, o.Equal(time.Date(2015, time.May, 1, 10, 0, 0, 0, london)), true, o)
+}
+
+func TestOpeningHoursOpenSpans(t *testing.T) {
+	oh := MustParseOpeningHours("Fr 22:00-24:00; Sa 00:00-02:00, 09:00-10:00; Su 00:00-24:00", london)
+
+	spans := oh.OpenSpans(NewDateRange(d0327, d0330))
+	isEq(t, 0, len(spans), 3)
+	isEq(t, 0, spans[0].Start().Equal(lt(27, 22, 0)), true, spans[0])
+	isEq(t, 0, spans[0].End().Equal(lt(28, 2, 0)), true, spans[0])
+	isEq(t, 1, spans[1].Start().Equal(lt(28, 9, 0)), true, spans[1])
+	isEq(t, 1, spans[1].Duration(), time.Hour)
+	// the clocks go forward on Sunday, which has only 23 hours
+	isEq(t, 2, spans[2].Start().Equal(lt(29, 0, 0)), true, spans[2])
+	isEq(t, 2, spans[2].Duration(), 23*time.Hour)
+
+	// clipped at the start of the range
+	spans = oh.OpenSpans(NewDateRange(d0328, d0329))
+	isEq(t, 0, len(spans), 2)
+	isEq(t, 0, spans[0].Start().Equal(lt(28, 0, 0)), true, spans[0])
+	isEq(t, 0, spans[0].End().Equal(lt(28, 2, 0)), true, spans[0])
+
+	isEq(t, 0, len(oh.OpenSpans(EmptyRange(d0328))), 0)
+}
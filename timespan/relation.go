@@ -0,0 +1,404 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"strings"
+	"time"
+
+	"github.com/simplylizz/date"
+)
+
+// Relation is one of the thirteen relations of Allen's interval algebra. Exactly one of them
+// holds between any two date ranges or time spans; see DateRange.Relate and TimeSpan.Relate.
+//
+// The relations follow the half-open semantics used throughout this package. An empty range
+// (e.g. from EmptyRange) behaves as a point: one at the start of a non-empty range Starts it,
+// one strictly inside During it, but one at the (exclusive) end Meets it.
+type Relation uint8
+
+// The relations are listed so that each is followed, symmetrically from the end of the list,
+// by its inverse; so Before is the inverse of After, Meets of MetBy, and so on.
+const (
+	Before       Relation = iota // a ends before b starts
+	Meets                        // a ends where b starts
+	Overlaps                     // a starts first and ends within b
+	FinishedBy                   // a starts first and ends with b
+	Includes                     // a starts before b and ends after it
+	Starts                       // a starts with b and ends first
+	Equals                       // a and b have the same start and end
+	StartedBy                    // a starts with b and ends after it
+	During                       // a starts after b and ends before it
+	Finishes                     // a starts after b and ends with it
+	OverlappedBy                 // a starts within b and ends after it
+	MetBy                        // a starts where b ends
+	After                        // a starts after b ends
+)
+
+var relationNames = [...]string{
+	"before", "meets", "overlaps", "finished-by", "includes", "starts", "equals",
+	"started-by", "during", "finishes", "overlapped-by", "met-by", "after",
+}
+
+// Inverse returns the relation that holds between b and a when r holds between a and b.
+func (r Relation) Inverse() Relation {
+	return After - r
+}
+
+// String returns the name of the relation, e.g. "overlapped-by".
+func (r Relation) String() string {
+	if r > After {
+		return "invalid"
+	}
+	return relationNames[r]
+}
+
+// relate determines the relation between intervals a and b from comparisons of their
+// endpoints: ss compares the starts, ee the ends, ab the end of a with the start of b
+// and ba the end of b with the start of a. Each comparison is -1, 0 or +1.
+func relate(ss, ee, ab, ba int, aEmpty, bEmpty bool) Relation {
+	switch {
+	case ss == 0 && ee == 0:
+		return Equals
+	case ab < 0:
+		return Before
+	case ba < 0:
+		return After
+	case ab == 0:
+		if aEmpty && !bEmpty {
+			return Starts // a is a point at the start of b, which contains it
+		}
+		return Meets
+	case ba == 0:
+		if bEmpty && !aEmpty {
+			return StartedBy
+		}
+		return MetBy
+	case ss == 0:
+		if ee < 0 {
+			return Starts
+		}
+		return StartedBy
+	case ee == 0:
+		if ss > 0 {
+			return Finishes
+		}
+		return FinishedBy
+	case ss > 0 && ee < 0:
+		return During
+	case ss < 0 && ee > 0:
+		return Includes
+	case ss < 0:
+		return Overlaps
+	}
+	return OverlappedBy
+}
+
+func compareDates(a, b date.Date) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// RelationSet is a set of relations, used for expressing uncertain knowledge such as the
+// result of Compose.
+type RelationSet uint16
+
+// AllRelations is the set of all thirteen relations, i.e. nothing is known.
+const AllRelations RelationSet = 1<<(After+1) - 1
+
+// NewRelationSet returns the set containing the given relations.
+func NewRelationSet(relations ...Relation) RelationSet {
+	var s RelationSet
+	for _, r := range relations {
+		s |= 1 << r
+	}
+	return s
+}
+
+// Has reports whether the set contains a relation.
+func (s RelationSet) Has(r Relation) bool {
+	return s&(1<<r) != 0
+}
+
+// IsEmpty reports whether the set contains no relations; this indicates that a set of
+// constraints is inconsistent.
+func (s RelationSet) IsEmpty() bool {
+	return s&AllRelations == 0
+}
+
+// Relations returns the relations in the set, in the order of their declaration.
+func (s RelationSet) Relations() []Relation {
+	var relations []Relation
+	for r := Before; r <= After; r++ {
+		if s.Has(r) {
+			relations = append(relations, r)
+		}
+	}
+	return relations
+}
+
+// Inverse returns the set containing the inverse of each relation in s.
+func (s RelationSet) Inverse() RelationSet {
+	var inverse RelationSet
+	for _, r := range s.Relations() {
+		inverse |= 1 << r.Inverse()
+	}
+	return inverse
+}
+
+// Compose returns the relations that can hold between a and c, given that one of the
+// relations in s holds between a and b and one of those in t holds between b and c.
+func (s RelationSet) Compose(t RelationSet) RelationSet {
+	var result RelationSet
+	for _, r1 := range s.Relations() {
+		for _, r2 := range t.Relations() {
+			result |= composition[r1][r2]
+		}
+	}
+	return result
+}
+
+// String lists the relations in the set, e.g. "{before meets overlaps}".
+func (s RelationSet) String() string {
+	names := make([]string, 0, 13)
+	for _, r := range s.Relations() {
+		names = append(names, r.String())
+	}
+	return "{" + strings.Join(names, " ") + "}"
+}
+
+// Compose returns the relations that can hold between a and c, given that r1 holds between
+// a and b and r2 holds between b and c. This is Allen's composition (or transitivity) table,
+// which is the basis for inferring relations from a network of constraints. For example,
+// if a Meets b and b During c, then a is Overlaps, Starts or During c.
+//
+// The table is for non-empty ranges; when empty ranges are involved, the true relation
+// between a and c may lie outside the result.
+func Compose(r1, r2 Relation) RelationSet {
+	return composition[r1][r2]
+}
+
+// composition is Allen's composition table. Rather than being transcribed, it is derived
+// by relating every triple of intervals whose endpoints lie on a small grid; six points
+// allow every ordering of the six endpoints of three intervals.
+var composition = buildComposition()
+
+func buildComposition() (table [After + 1][After + 1]RelationSet) {
+	const points = 6
+	type interval struct{ start, end int }
+
+	var intervals []interval
+	for s := 0; s < points; s++ {
+		for e := s + 1; e < points; e++ {
+			intervals = append(intervals, interval{s, e})
+		}
+	}
+
+	rel := func(a, b interval) Relation {
+		return relate(compareInts(a.start, b.start), compareInts(a.end, b.end),
+			compareInts(a.end, b.start), compareInts(b.end, a.start), false, false)
+	}
+
+	for _, a := range intervals {
+		for _, b := range intervals {
+			ab := rel(a, b)
+			for _, c := range intervals {
+				table[ab][rel(b, c)] |= 1 << rel(a, c)
+			}
+		}
+	}
+	return table
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Relate returns the Allen relation between this date range and another.
+// Inverted ranges are treated as if normalised.
+func (dateRange DateRange) Relate(other DateRange) Relation {
+	as, ae := dateRange.Start(), dateRange.End()
+	bs, be := other.Start(), other.End()
+	return relate(compareDates(as, bs), compareDates(ae, be), compareDates(ae, bs), compareDates(be, as),
+		dateRange.days == 0, other.days == 0)
+}
+
+// IsBefore reports whether this date range ends before the other starts, with at least
+// one date between them.
+func (dateRange DateRange) IsBefore(other DateRange) bool {
+	return dateRange.Relate(other) == Before
+}
+
+// IsAfter reports whether this date range starts after the other ends, with at least
+// one date between them.
+func (dateRange DateRange) IsAfter(other DateRange) bool {
+	return dateRange.Relate(other) == After
+}
+
+// Meets reports whether the other date range starts on the end date of this one.
+func (dateRange DateRange) Meets(other DateRange) bool {
+	return dateRange.Relate(other) == Meets
+}
+
+// IsMetBy reports whether this date range starts on the end date of the other.
+func (dateRange DateRange) IsMetBy(other DateRange) bool {
+	return dateRange.Relate(other) == MetBy
+}
+
+// OverlapsStartOf reports whether this date range starts before the other and ends
+// within it (the Overlaps relation).
+func (dateRange DateRange) OverlapsStartOf(other DateRange) bool {
+	return dateRange.Relate(other) == Overlaps
+}
+
+// OverlapsEndOf reports whether this date range starts within the other and ends after
+// it (the OverlappedBy relation).
+func (dateRange DateRange) OverlapsEndOf(other DateRange) bool {
+	return dateRange.Relate(other) == OverlappedBy
+}
+
+// Starts reports whether this date range starts with the other and ends before it.
+func (dateRange DateRange) Starts(other DateRange) bool {
+	return dateRange.Relate(other) == Starts
+}
+
+// IsStartedBy reports whether this date range starts with the other and ends after it.
+func (dateRange DateRange) IsStartedBy(other DateRange) bool {
+	return dateRange.Relate(other) == StartedBy
+}
+
+// IsDuring reports whether this date range starts after the other and ends before it.
+func (dateRange DateRange) IsDuring(other DateRange) bool {
+	return dateRange.Relate(other) == During
+}
+
+// Includes reports whether this date range starts before the other and ends after it.
+// Unlike ContainsRange, this is false if the ranges share a start or end.
+func (dateRange DateRange) Includes(other DateRange) bool {
+	return dateRange.Relate(other) == Includes
+}
+
+// Finishes reports whether this date range starts after the other and ends with it.
+func (dateRange DateRange) Finishes(other DateRange) bool {
+	return dateRange.Relate(other) == Finishes
+}
+
+// IsFinishedBy reports whether this date range starts before the other and ends with it.
+func (dateRange DateRange) IsFinishedBy(other DateRange) bool {
+	return dateRange.Relate(other) == FinishedBy
+}
+
+// Coincides reports whether the two date ranges have the same start and end. Unlike
+// ==, this is true for a range and its inverted equivalent.
+func (dateRange DateRange) Coincides(other DateRange) bool {
+	return dateRange.Relate(other) == Equals
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Relate returns the Allen relation between this time span and another.
+// Negative spans are treated as if normalised.
+func (ts TimeSpan) Relate(other TimeSpan) Relation {
+	as, ae := ts.Start(), ts.End()
+	bs, be := other.Start(), other.End()
+	return relate(compareTimes(as, bs), compareTimes(ae, be), compareTimes(ae, bs), compareTimes(be, as),
+		ts.duration == 0, other.duration == 0)
+}
+
+// IsBefore reports whether this time span ends before the other starts, with a gap
+// between them.
+func (ts TimeSpan) IsBefore(other TimeSpan) bool {
+	return ts.Relate(other) == Before
+}
+
+// IsAfter reports whether this time span starts after the other ends, with a gap
+// between them.
+func (ts TimeSpan) IsAfter(other TimeSpan) bool {
+	return ts.Relate(other) == After
+}
+
+// Meets reports whether the other time span starts at the end of this one.
+func (ts TimeSpan) Meets(other TimeSpan) bool {
+	return ts.Relate(other) == Meets
+}
+
+// IsMetBy reports whether this time span starts at the end of the other.
+func (ts TimeSpan) IsMetBy(other TimeSpan) bool {
+	return ts.Relate(other) == MetBy
+}
+
+// OverlapsStartOf reports whether this time span starts before the other and ends
+// within it (the Overlaps relation).
+func (ts TimeSpan) OverlapsStartOf(other TimeSpan) bool {
+	return ts.Relate(other) == Overlaps
+}
+
+// OverlapsEndOf reports whether this time span starts within the other and ends after
+// it (the OverlappedBy relation).
+func (ts TimeSpan) OverlapsEndOf(other TimeSpan) bool {
+	return ts.Relate(other) == OverlappedBy
+}
+
+// Starts reports whether this time span starts with the other and ends before it.
+func (ts TimeSpan) Starts(other TimeSpan) bool {
+	return ts.Relate(other) == Starts
+}
+
+// IsStartedBy reports whether this time span starts with the other and ends after it.
+func (ts TimeSpan) IsStartedBy(other TimeSpan) bool {
+	return ts.Relate(other) == StartedBy
+}
+
+// IsDuring reports whether this time span starts after the other and ends before it.
+func (ts TimeSpan) IsDuring(other TimeSpan) bool {
+	return ts.Relate(other) == During
+}
+
+// Includes reports whether this time span starts before the other and ends after it.
+// Unlike ContainsRange, this is false if the spans share a start or end.
+func (ts TimeSpan) Includes(other TimeSpan) bool {
+	return ts.Relate(other) == Includes
+}
+
+// Finishes reports whether this time span starts after the other and ends with it.
+func (ts TimeSpan) Finishes(other TimeSpan) bool {
+	return ts.Relate(other) == Finishes
+}
+
+// IsFinishedBy reports whether this time span starts before the other and ends with it.
+func (ts TimeSpan) IsFinishedBy(other TimeSpan) bool {
+	return ts.Relate(other) == FinishedBy
+}
+
+// Coincides reports whether the two time spans have the same start and end instants.
+// This is the same as Equal for normalised spans.
+func (ts TimeSpan) Coincides(other TimeSpan) bool {
+	return ts.Relate(other) == Equals
+}
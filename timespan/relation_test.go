@@ -0,0 +1,178 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateRangeRelate(t *testing.T) {
+	b := NewDateRange(d0325, d0330)
+	cases := []struct {
+		a    DateRange
+		want Relation
+	}{
+		{NewDateRange(d0320, d0321), Before},
+		{NewDateRange(d0320, d0325), Meets},
+		{NewDateRange(d0320, d0327), Overlaps},
+		{NewDateRange(d0320, d0330), FinishedBy},
+		{NewDateRange(d0320, d0401), Includes},
+		{NewDateRange(d0325, d0327), Starts},
+		{NewDateRange(d0325, d0330), Equals},
+		{DateRange{d0329, -5}, Equals},
+		{NewDateRange(d0325, d0401), StartedBy},
+		{NewDateRange(d0326, d0328), During},
+		{NewDateRange(d0327, d0330), Finishes},
+		{NewDateRange(d0327, d0401), OverlappedBy},
+		{NewDateRange(d0330, d0401), MetBy},
+		{NewDateRange(d0331, d0401), After},
+
+		// empty ranges behave as points, consistently with Contains
+		{EmptyRange(d0321), Before},
+		{EmptyRange(d0325), Starts},
+		{EmptyRange(d0327), During},
+		{EmptyRange(d0330), MetBy},
+		{EmptyRange(d0401), After},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.a.Relate(b), c.want, c.a)
+		isEq(t, i, b.Relate(c.a), c.want.Inverse(), c.a)
+	}
+
+	isEq(t, 0, EmptyRange(d0325).Relate(EmptyRange(d0325)), Equals)
+	isEq(t, 0, EmptyRange(d0325).Relate(EmptyRange(d0327)), Before)
+}
+
+func TestDateRangeRelationPredicates(t *testing.T) {
+	b := NewDateRange(d0325, d0330)
+	isEq(t, 0, NewDateRange(d0320, d0321).IsBefore(b), true)
+	isEq(t, 0, NewDateRange(d0331, d0401).IsAfter(b), true)
+	isEq(t, 0, NewDateRange(d0320, d0325).Meets(b), true)
+	isEq(t, 0, NewDateRange(d0330, d0401).IsMetBy(b), true)
+	isEq(t, 0, NewDateRange(d0320, d0327).OverlapsStartOf(b), true)
+	isEq(t, 0, NewDateRange(d0327, d0401).OverlapsEndOf(b), true)
+	isEq(t, 0, NewDateRange(d0325, d0327).Starts(b), true)
+	isEq(t, 0, NewDateRange(d0325, d0401).IsStartedBy(b), true)
+	isEq(t, 0, NewDateRange(d0326, d0328).IsDuring(b), true)
+	isEq(t, 0, NewDateRange(d0320, d0401).Includes(b), true)
+	isEq(t, 0, NewDateRange(d0327, d0330).Finishes(b), true)
+	isEq(t, 0, NewDateRange(d0320, d0330).IsFinishedBy(b), true)
+	isEq(t, 0, DateRange{d0329, -5}.Coincides(b), true)
+
+	isEq(t, 0, NewDateRange(d0320, d0325).IsBefore(b), false)
+	isEq(t, 0, NewDateRange(d0325, d0330).Includes(b), false)
+}
+
+func TestTimeSpanRelate(t *testing.T) {
+	b := NewTimeSpan(t0328, t0329)
+	h := time.Hour
+	cases := []struct {
+		a    TimeSpan
+		want Relation
+	}{
+		{NewTimeSpan(t0327, t0327.Add(h)), Before},
+		{NewTimeSpan(t0327, t0328), Meets},
+		{NewTimeSpan(t0327, t0328.Add(h)), Overlaps},
+		{NewTimeSpan(t0327, t0329), FinishedBy},
+		{NewTimeSpan(t0327, t0330), Includes},
+		{NewTimeSpan(t0328, t0328.Add(h)), Starts},
+		{TimeSpanOf(t0329, -24*h), Equals},
+		{NewTimeSpan(t0328, t0330), StartedBy},
+		{NewTimeSpan(t0328.Add(h), t0328.Add(2*h)), During},
+		{NewTimeSpan(t0328.Add(h), t0329), Finishes},
+		{NewTimeSpan(t0328.Add(h), t0330), OverlappedBy},
+		{NewTimeSpan(t0329, t0330), MetBy},
+		{NewTimeSpan(t0329.Add(h), t0330), After},
+		{ZeroTimeSpan(t0328), Starts},
+		{ZeroTimeSpan(t0329), MetBy},
+
+		// the relation depends on the instants, not the locations
+		{NewTimeSpan(t0328.In(london), t0329.In(london)), Equals},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.a.Relate(b), c.want, c.a)
+		isEq(t, i, b.Relate(c.a), c.want.Inverse(), c.a)
+	}
+
+	isEq(t, 0, NewTimeSpan(t0327, t0328.Add(h)).OverlapsStartOf(b), true)
+	isEq(t, 0, NewTimeSpan(t0328.Add(h), t0328.Add(2*h)).IsDuring(b), true)
+	isEq(t, 0, NewTimeSpan(t0329, t0330).IsMetBy(b), true)
+	isEq(t, 0, TimeSpanOf(t0329, -24*h).Coincides(b), true)
+}
+
+func TestRelationString(t *testing.T) {
+	isEq(t, 0, Before.String(), "before")
+	isEq(t, 0, OverlappedBy.String(), "overlapped-by")
+	isEq(t, 0, Relation(13).String(), "invalid")
+	isEq(t, 0, NewRelationSet(Meets, Before, During).String(), "{before meets during}")
+	isEq(t, 0, RelationSet(0).String(), "{}")
+}
+
+func TestRelationInverse(t *testing.T) {
+	cases := []struct{ r, inverse Relation }{
+		{Before, After},
+		{Meets, MetBy},
+		{Overlaps, OverlappedBy},
+		{Starts, StartedBy},
+		{During, Includes},
+		{Finishes, FinishedBy},
+		{Equals, Equals},
+	}
+	for i, c := range cases {
+		isEq(t, i, c.r.Inverse(), c.inverse)
+		isEq(t, i, c.inverse.Inverse(), c.r)
+	}
+	isEq(t, 0, NewRelationSet(Before, Starts).Inverse(), NewRelationSet(After, StartedBy))
+}
+
+func TestCompose(t *testing.T) {
+	cases := []struct {
+		r1, r2 Relation
+		want   RelationSet
+	}{
+		{Before, Before, NewRelationSet(Before)},
+		{Meets, Meets, NewRelationSet(Before)},
+		{Equals, During, NewRelationSet(During)},
+		{Starts, Finishes, NewRelationSet(During)},
+		{Meets, During, NewRelationSet(Overlaps, Starts, During)},
+		{Overlaps, Overlaps, NewRelationSet(Before, Meets, Overlaps)},
+		{During, Includes, AllRelations},
+		{Before, After, AllRelations},
+		{During, During, NewRelationSet(During)},
+		{Finishes, Meets, NewRelationSet(Meets)},
+		{Overlaps, During, NewRelationSet(Overlaps, Starts, During)},
+	}
+	for i, c := range cases {
+		isEq(t, i, Compose(c.r1, c.r2), c.want, c.r1, c.r2)
+	}
+
+	// every entry is non-empty and composition respects inverses: (r1;r2)⁻¹ = r2⁻¹;r1⁻¹
+	for r1 := Before; r1 <= After; r1++ {
+		isEq(t, 0, Compose(r1, Equals), NewRelationSet(r1))
+		isEq(t, 0, Compose(Equals, r1), NewRelationSet(r1))
+		for r2 := Before; r2 <= After; r2++ {
+			isEq(t, 0, Compose(r1, r2).IsEmpty(), false, r1, r2)
+			isEq(t, 0, Compose(r1, r2).Inverse(), Compose(r2.Inverse(), r1.Inverse()), r1, r2)
+		}
+	}
+
+	s := NewRelationSet(Before, Meets).Compose(NewRelationSet(Meets))
+	isEq(t, 0, s, NewRelationSet(Before))
+	isEq(t, 0, s.Has(Before), true)
+	isEq(t, 0, s.Has(Meets), false)
+	isEq(t, 0, AllRelations.IsEmpty(), false)
+	isEq(t, 0, len(AllRelations.Relations()), 13)
+}
+
+func TestComposeInferenceFromRanges(t *testing.T) {
+	a := NewDateRange(d0320, d0325)
+	b := NewDateRange(d0325, d0328)
+	c := NewDateRange(d0321, d0401)
+	inferred := Compose(a.Relate(b), b.Relate(c))
+	isEq(t, 0, inferred.Has(a.Relate(c)), true, inferred, a.Relate(c))
+}
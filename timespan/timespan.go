@@ -16,8 +16,6 @@ import (
 // TimestampFormat is a simple format for date & time, "2006-01-02 15:04:05".
 const TimestampFormat = "2006-01-02 15:04:05"
 
-//const ISOFormat = "2006-01-02T15:04:05"
-
 // TimeSpan holds a span of time between two instants with a 1 nanosecond resolution.
 // It is implemented using a time.Duration, therefore is limited to a maximum span of 290 years.
 type TimeSpan struct {
@@ -153,6 +151,113 @@ func (ts TimeSpan) Merge(other TimeSpan) TimeSpan {
 	}
 }
 
+// Overlaps reports whether the two time spans have at least one instant in common.
+// Empty time spans (i.e. zero duration) never overlap anything.
+func (ts TimeSpan) Overlaps(other TimeSpan) bool {
+	if ts.duration == 0 || other.duration == 0 {
+		return false
+	}
+	return ts.Start().Before(other.End()) && other.Start().Before(ts.End())
+}
+
+// Intersect returns the time that is in both time spans. If they don't overlap (see
+// Overlaps), an empty time span is returned and ok is false. The result is normalised
+// and has the same location as ts.
+func (ts TimeSpan) Intersect(other TimeSpan) (result TimeSpan, ok bool) {
+	if !ts.Overlaps(other) {
+		return TimeSpan{}, false
+	}
+	loc := ts.mark.Location()
+	start := laterOf(ts.Start(), other.Start()).In(loc)
+	end := earlierOf(ts.End(), other.End()).In(loc)
+	return NewTimeSpan(start, end), true
+}
+
+// Subtract returns the time that is in this time span but not in the other. The result
+// has no pieces if other contains the whole span, two pieces if other is strictly inside
+// the span, and otherwise one piece. The pieces are normalised, in ascending order and
+// have the same location as ts; none of them is empty.
+func (ts TimeSpan) Subtract(other TimeSpan) []TimeSpan {
+	if ts.duration == 0 {
+		return nil
+	}
+	if !ts.Overlaps(other) {
+		return []TimeSpan{ts.Normalise()}
+	}
+
+	loc := ts.mark.Location()
+	var pieces []TimeSpan
+	if ts.Start().Before(other.Start()) {
+		pieces = append(pieces, NewTimeSpan(ts.Start(), other.Start().In(loc)))
+	}
+	if other.End().Before(ts.End()) {
+		pieces = append(pieces, NewTimeSpan(other.End().In(loc), ts.End()))
+	}
+	return pieces
+}
+
+// Gap returns the time lying between two time spans that neither overlap nor abut, i.e.
+// from the end of the earlier span up to the start of the later one. If there is no such
+// time, an empty time span is returned and ok is false. The result has the same location
+// as ts.
+func (ts TimeSpan) Gap(other TimeSpan) (result TimeSpan, ok bool) {
+	loc := ts.mark.Location()
+	switch {
+	case ts.End().Before(other.Start()):
+		return NewTimeSpan(ts.End(), other.Start().In(loc)), true
+	case other.End().Before(ts.Start()):
+		return NewTimeSpan(other.End().In(loc), ts.Start()), true
+	}
+	return TimeSpan{}, false
+}
+
+// Abuts reports whether the two time spans are adjacent, so that one starts at the
+// (exclusive) end of the other. Empty time spans never abut anything.
+func (ts TimeSpan) Abuts(other TimeSpan) bool {
+	if ts.duration == 0 || other.duration == 0 {
+		return false
+	}
+	return ts.End().Equal(other.Start()) || other.End().Equal(ts.Start())
+}
+
+// ContainsRange tests whether every instant in the other time span is also in this time
+// span. An empty other span is contained if its start time is contained (see Contains).
+// Empty time spans never contain anything.
+func (ts TimeSpan) ContainsRange(other TimeSpan) bool {
+	if ts.duration == 0 {
+		return false
+	}
+	if other.duration == 0 {
+		return ts.Normalise().Contains(other.Start())
+	}
+	return !other.Start().Before(ts.Start()) && !ts.End().Before(other.End())
+}
+
+// Clamp restricts the other time span so that it lies within this time span. This is
+// like Intersect, except that when the two don't overlap, the result is an empty span
+// at whichever end of this span is nearer to the other span. The result is normalised
+// and has the same location as ts.
+func (ts TimeSpan) Clamp(other TimeSpan) TimeSpan {
+	loc := ts.mark.Location()
+	start := earlierOf(laterOf(other.Start(), ts.Start()), ts.End()).In(loc)
+	end := earlierOf(laterOf(other.End(), start), ts.End()).In(loc)
+	return NewTimeSpan(start, end)
+}
+
+func earlierOf(t1, t2 time.Time) time.Time {
+	if t2.Before(t1) {
+		return t2
+	}
+	return t1
+}
+
+func laterOf(t1, t2 time.Time) time.Time {
+	if t2.After(t1) {
+		return t2
+	}
+	return t1
+}
+
 // RFC5545DateTimeLayout is the format string used by iCalendar (RFC5545). Note
 // that "Z" is to be appended when the time is UTC.
 const RFC5545DateTimeLayout = "20060102T150405"
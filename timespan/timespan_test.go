@@ -371,3 +371,105 @@ func TestConversion3(t *testing.T) {
 	isEq(t, 0, ts1, ts2)
 	isEq(t, 0, ts1.Duration(), time.Hour*71)
 }
+
+func TestTimeSpanIntervalOperations(t *testing.T) {
+	t0327_12 := t0327.Add(12 * time.Hour)
+	t0328_12 := t0328.Add(12 * time.Hour)
+
+	cases := []struct {
+		a, b          TimeSpan
+		overlaps      bool
+		abuts         bool
+		intersect     TimeSpan
+		gap           TimeSpan
+		subtract      []TimeSpan
+		containsRange bool
+		clamp         TimeSpan
+	}{
+		// b inside a
+		{NewTimeSpan(t0327, t0330), NewTimeSpan(t0327_12, t0328_12), true, false,
+			NewTimeSpan(t0327_12, t0328_12), TimeSpan{},
+			[]TimeSpan{NewTimeSpan(t0327, t0327_12), NewTimeSpan(t0328_12, t0330)}, true,
+			NewTimeSpan(t0327_12, t0328_12)},
+		// partial overlap, with b inverted
+		{NewTimeSpan(t0327, t0328_12), TimeSpanOf(t0330, -48*time.Hour), true, false,
+			NewTimeSpan(t0328, t0328_12), TimeSpan{},
+			[]TimeSpan{NewTimeSpan(t0327, t0328)}, false,
+			NewTimeSpan(t0328, t0328_12)},
+		// a inside b
+		{NewTimeSpan(t0328, t0329), NewTimeSpan(t0327, t0330), true, false,
+			NewTimeSpan(t0328, t0329), TimeSpan{},
+			nil, false,
+			NewTimeSpan(t0328, t0329)},
+		// adjacent
+		{NewTimeSpan(t0327, t0328), NewTimeSpan(t0328, t0330), false, true,
+			TimeSpan{}, TimeSpan{},
+			[]TimeSpan{NewTimeSpan(t0327, t0328)}, false,
+			ZeroTimeSpan(t0328)},
+		// disjoint, b before a
+		{NewTimeSpan(t0329, t0330), NewTimeSpan(t0327, t0328), false, false,
+			TimeSpan{}, NewTimeSpan(t0328, t0329),
+			[]TimeSpan{NewTimeSpan(t0329, t0330)}, false,
+			ZeroTimeSpan(t0329)},
+		// b is empty, at the start of a
+		{NewTimeSpan(t0327, t0328), ZeroTimeSpan(t0327), false, false,
+			TimeSpan{}, TimeSpan{},
+			[]TimeSpan{NewTimeSpan(t0327, t0328)}, true,
+			ZeroTimeSpan(t0327)},
+		// b is empty, at the exclusive end of a
+		{NewTimeSpan(t0327, t0328), ZeroTimeSpan(t0328), false, false,
+			TimeSpan{}, TimeSpan{},
+			[]TimeSpan{NewTimeSpan(t0327, t0328)}, false,
+			ZeroTimeSpan(t0328)},
+		// both are empty, at the same instant
+		{ZeroTimeSpan(t0328), ZeroTimeSpan(t0328), false, false,
+			TimeSpan{}, TimeSpan{},
+			nil, false,
+			ZeroTimeSpan(t0328)},
+		// a is empty, at the start of b
+		{ZeroTimeSpan(t0327), NewTimeSpan(t0327, t0328), false, false,
+			TimeSpan{}, TimeSpan{},
+			nil, false,
+			ZeroTimeSpan(t0327)},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.a.Overlaps(c.b), c.overlaps, c.a, c.b)
+		isEq(t, i, c.b.Overlaps(c.a), c.overlaps, c.a, c.b)
+		isEq(t, i, c.a.Abuts(c.b), c.abuts, c.a, c.b)
+		isEq(t, i, c.b.Abuts(c.a), c.abuts, c.a, c.b)
+
+		r, ok := c.a.Intersect(c.b)
+		isEq(t, i, r, c.intersect, c.a, c.b)
+		isEq(t, i, ok, c.overlaps, c.a, c.b)
+
+		g, ok := c.a.Gap(c.b)
+		isEq(t, i, g, c.gap, c.a, c.b)
+		isEq(t, i, ok, g != TimeSpan{}, c.a, c.b)
+
+		s := c.a.Subtract(c.b)
+		isEq(t, i, len(s), len(c.subtract), c.a, c.b, s)
+		for j := 0; j < len(s) && j < len(c.subtract); j++ {
+			isEq(t, i, s[j], c.subtract[j], c.a, c.b)
+		}
+
+		isEq(t, i, c.a.ContainsRange(c.b), c.containsRange, c.a, c.b)
+		isEq(t, i, c.a.Clamp(c.b), c.clamp, c.a, c.b)
+	}
+}
+
+func TestTimeSpanIntervalOperationsInOtherLocation(t *testing.T) {
+	a := NewTimeSpan(t0327, t0329)
+	b := NewTimeSpan(t0328.In(london), t0330.In(london))
+
+	r, ok := a.Intersect(b)
+	isEq(t, 0, ok, true)
+	isEq(t, 0, r.Start(), t0328)
+	isEq(t, 0, r.End(), t0329)
+
+	s := b.Subtract(a)
+	isEq(t, 0, len(s), 1)
+	isEq(t, 0, s[0].Start().Location(), london)
+	isEq(t, 0, s[0].Start().Equal(t0329), true)
+	isEq(t, 0, s[0].Duration(), 24*time.Hour)
+}
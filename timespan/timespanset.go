@@ -0,0 +1,186 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeSpanSet is a set of instants held as time spans. The spans are kept normalised,
+// sorted and coalesced, so no two of them overlap or abut and none is empty. The start
+// and end times keep the locations of the spans they came from.
+//
+// TimeSpanSet values are immutable: the methods that alter a set return a new set.
+// The zero value is an empty set.
+type TimeSpanSet struct {
+	spans []TimeSpan
+}
+
+// NewTimeSpanSet creates a set containing all the instants in some time spans. The spans
+// can be in any order and may overlap; negative spans are normalised.
+func NewTimeSpanSet(spans ...TimeSpan) TimeSpanSet {
+	return TimeSpanSet{coalesceTimeSpans(spans)}
+}
+
+// coalesceTimeSpans returns new normalised spans, sorted by their start times, with
+// overlapping and abutting spans merged together and empty spans removed.
+func coalesceTimeSpans(spans []TimeSpan) []TimeSpan {
+	sorted := make([]TimeSpan, 0, len(spans))
+	for _, ts := range spans {
+		if ts.duration != 0 {
+			sorted = append(sorted, ts.Normalise())
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].mark.Before(sorted[j].mark)
+	})
+
+	var result []TimeSpan
+	for _, ts := range sorted {
+		n := len(result)
+		if n > 0 && !result[n-1].End().Before(ts.mark) {
+			result[n-1] = NewTimeSpan(result[n-1].mark, laterOf(result[n-1].End(), ts.End()))
+		} else {
+			result = append(result, ts)
+		}
+	}
+	return result
+}
+
+// TimeSpans returns the time spans in the set, in ascending order.
+func (set TimeSpanSet) TimeSpans() []TimeSpan {
+	return append([]TimeSpan(nil), set.spans...)
+}
+
+// Len returns the number of time spans in the set.
+func (set TimeSpanSet) Len() int {
+	return len(set.spans)
+}
+
+// IsEmpty returns true if the set contains no time.
+func (set TimeSpanSet) IsEmpty() bool {
+	return len(set.spans) == 0
+}
+
+// Duration returns the total duration of the time spans in the set.
+func (set TimeSpanSet) Duration() time.Duration {
+	var d time.Duration
+	for _, ts := range set.spans {
+		d += ts.duration
+	}
+	return d
+}
+
+// Hull returns the smallest time span containing every instant in the set. For an empty
+// set, this is the zero value.
+func (set TimeSpanSet) Hull() TimeSpan {
+	if len(set.spans) == 0 {
+		return TimeSpan{}
+	}
+	return NewTimeSpan(set.spans[0].mark, set.spans[len(set.spans)-1].End())
+}
+
+// Contains tests whether the set contains a specified instant.
+func (set TimeSpanSet) Contains(t time.Time) bool {
+	i := sort.Search(len(set.spans), func(i int) bool {
+		return t.Before(set.spans[i].End())
+	})
+	return i < len(set.spans) && set.spans[i].Contains(t)
+}
+
+// ContainsRange tests whether the set contains every instant in a time span. As with
+// TimeSpan.ContainsRange, an empty span is contained if its start time is contained.
+func (set TimeSpanSet) ContainsRange(ts TimeSpan) bool {
+	start := ts.Start()
+	i := sort.Search(len(set.spans), func(i int) bool {
+		return start.Before(set.spans[i].End())
+	})
+	return i < len(set.spans) && set.spans[i].ContainsRange(ts)
+}
+
+// Equal reports whether two sets contain the same instants, regardless of location.
+func (set TimeSpanSet) Equal(other TimeSpanSet) bool {
+	if len(set.spans) != len(other.spans) {
+		return false
+	}
+	for i, ts := range set.spans {
+		if !ts.Equal(other.spans[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add returns a set containing the instants in this set and those in some time spans.
+func (set TimeSpanSet) Add(spans ...TimeSpan) TimeSpanSet {
+	return TimeSpanSet{coalesceTimeSpans(append(set.TimeSpans(), spans...))}
+}
+
+// Remove returns a set containing the instants in this set except those in some time spans.
+func (set TimeSpanSet) Remove(spans ...TimeSpan) TimeSpanSet {
+	return set.Difference(NewTimeSpanSet(spans...))
+}
+
+// Union returns a set containing the instants that are in either set.
+func (set TimeSpanSet) Union(other TimeSpanSet) TimeSpanSet {
+	return set.Add(other.spans...)
+}
+
+// Intersect returns a set containing the instants that are in both sets. For example,
+// the intersection of the sets of times when each person is free gives the times when
+// everyone is available.
+func (set TimeSpanSet) Intersect(other TimeSpanSet) TimeSpanSet {
+	var result []TimeSpan
+	i, j := 0, 0
+	for i < len(set.spans) && j < len(other.spans) {
+		a, b := set.spans[i], other.spans[j]
+		if r, ok := a.Intersect(b); ok {
+			result = append(result, r)
+		}
+		if a.End().Before(b.End()) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return TimeSpanSet{result}
+}
+
+// Difference returns a set containing the instants that are in this set but not the other.
+func (set TimeSpanSet) Difference(other TimeSpanSet) TimeSpanSet {
+	return set.Intersect(other.Complement(set.Hull()))
+}
+
+// Complement returns a set containing the instants within some bounds that are not in
+// this set. The gaps start and end in the location of the adjacent spans or bounds.
+func (set TimeSpanSet) Complement(bounds TimeSpan) TimeSpanSet {
+	var result []TimeSpan
+	cursor, end := bounds.Start(), bounds.End()
+	for _, ts := range set.spans {
+		if !ts.mark.Before(end) {
+			break
+		}
+		if cursor.Before(ts.mark) {
+			result = append(result, NewTimeSpan(cursor, ts.mark))
+		}
+		cursor = laterOf(cursor, ts.End())
+	}
+	if cursor.Before(end) {
+		result = append(result, NewTimeSpan(cursor, end))
+	}
+	return TimeSpanSet{result}
+}
+
+// String lists the time spans in the set.
+func (set TimeSpanSet) String() string {
+	s := make([]string, len(set.spans))
+	for i, ts := range set.spans {
+		s[i] = ts.String()
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
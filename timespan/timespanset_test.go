@@ -0,0 +1,73 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTimeSpanSet(t *testing.T) {
+	h := time.Hour
+	s := NewTimeSpanSet(
+		NewTimeSpan(t0328, t0328.Add(2*h)),
+		TimeSpanOf(t0327.Add(3*h), -h),
+		NewTimeSpan(t0327.Add(3*h), t0327.Add(5*h)),
+		NewTimeSpan(t0328.Add(h), t0328.Add(90*time.Minute)),
+		ZeroTimeSpan(t0330),
+	)
+
+	got := s.TimeSpans()
+	isEq(t, 0, len(got), 2, s)
+	isEq(t, 0, got[0], NewTimeSpan(t0327.Add(2*h), t0327.Add(5*h)))
+	isEq(t, 0, got[1], NewTimeSpan(t0328, t0328.Add(2*h)))
+	isEq(t, 0, s.Duration(), 5*h)
+	isEq(t, 0, s.Hull(), NewTimeSpan(t0327.Add(2*h), t0328.Add(2*h)))
+
+	isEq(t, 0, s.Contains(t0327.Add(2*h)), true)
+	isEq(t, 0, s.Contains(t0327.Add(5*h)), false)
+	isEq(t, 0, s.Contains(t0328.Add(h).In(london)), true)
+	isEq(t, 0, s.ContainsRange(NewTimeSpan(t0327.Add(3*h), t0327.Add(5*h))), true)
+	isEq(t, 0, s.ContainsRange(NewTimeSpan(t0327.Add(3*h), t0328)), false)
+
+	var empty TimeSpanSet
+	isEq(t, 0, empty.IsEmpty(), true)
+	isEq(t, 0, empty.Duration(), zero)
+	isEq(t, 0, empty.Contains(t0327), false)
+}
+
+func TestTimeSpanSetEveryoneAvailable(t *testing.T) {
+	at := func(hh, mm int) time.Time {
+		return time.Date(2015, 3, 30, hh, mm, 0, 0, london)
+	}
+	day := NewTimeSpan(at(9, 0), at(17, 0))
+
+	aliceBusy := NewTimeSpanSet(NewTimeSpan(at(10, 0), at(11, 0)), NewTimeSpan(at(13, 0), at(14, 30)))
+	bobBusy := NewTimeSpanSet(NewTimeSpan(at(9, 0), at(9, 30)), NewTimeSpan(at(14, 0), at(15, 0)))
+
+	available := aliceBusy.Complement(day).Intersect(bobBusy.Complement(day))
+	want := NewTimeSpanSet(
+		NewTimeSpan(at(9, 30), at(10, 0)),
+		NewTimeSpan(at(11, 0), at(13, 0)),
+		NewTimeSpan(at(15, 0), at(17, 0)),
+	)
+	isEq(t, 0, available.Equal(want), true, available)
+	isEq(t, 0, available.Equal(NewTimeSpanSet(day).Difference(aliceBusy.Union(bobBusy))), true)
+	isEq(t, 0, NewTimeSpanSet(day).Remove(aliceBusy.TimeSpans()...).Remove(bobBusy.TimeSpans()...).Equal(want), true)
+	isEq(t, 0, available.Duration(), 270*time.Minute)
+	isEq(t, 0, available.Len(), 3)
+}
+
+func TestTimeSpanSetAcrossLocations(t *testing.T) {
+	a := NewTimeSpanSet(NewTimeSpan(t0327, t0328))
+	b := NewTimeSpanSet(NewTimeSpan(t0328.In(london), t0329.In(london)))
+
+	u := a.Union(b)
+	isEq(t, 0, u.Len(), 1)
+	isEq(t, 0, u.Duration(), 48*time.Hour)
+	isEq(t, 0, a.Intersect(b).IsEmpty(), true)
+	isEq(t, 0, u.Difference(b).Equal(a), true)
+	isEq(t, 0, u.Add(NewTimeSpan(t0329, t0330)).Duration(), 72*time.Hour)
+}
@@ -0,0 +1,105 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/simplylizz/date"
+)
+
+// Transition is a change of a location's UTC offset or zone abbreviation, typically when
+// the clocks go forward or back for daylight saving.
+type Transition struct {
+	At        time.Time // the instant of the change, in the location
+	Date      date.Date // the local date on which the change happens
+	OldOffset int       // the offset before the change, in seconds east of UTC
+	NewOffset int       // the offset after the change, in seconds east of UTC
+	OldName   string    // the zone abbreviation before the change, e.g. "GMT"
+	NewName   string    // the zone abbreviation after the change, e.g. "BST"
+}
+
+// Change returns the amount by which the clocks change, i.e. the new offset minus the old
+// offset. This is positive when the clocks go forward, and the day is correspondingly shorter.
+func (tr Transition) Change() time.Duration {
+	return time.Duration(tr.NewOffset-tr.OldOffset) * time.Second
+}
+
+// String gets a description of the transition, e.g. "2026-03-29T02:00:00+01:00 GMT→BST".
+func (tr Transition) String() string {
+	return fmt.Sprintf("%s %s→%s", tr.At.Format(time.RFC3339), tr.OldName, tr.NewName)
+}
+
+// transitionStep is the interval at which the zone is sampled. It is shorter than the
+// time between any two transitions in practice.
+const transitionStep = 6 * time.Hour
+
+// TransitionsIn finds the changes of UTC offset or zone abbreviation that happen in a
+// location during a date range, i.e. from the start of its first day up to the end of its last.
+// The results are ordered by time. A date range that contains no transitions gives an
+// empty result, as does any date range in a location without daylight saving, such as UTC.
+//
+// A change at the very first instant of a day, e.g. when the clocks go forward from
+// midnight, is reported for that day.
+func TransitionsIn(dr DateRange, loc *time.Location) []Transition {
+	dr = dr.Normalise()
+	start, end := dr.StartTimeIn(loc), dr.EndTimeIn(loc)
+
+	var result []Transition
+	// start is already in the new zone if the change happens at the start of the day,
+	// so the scan starts just before it
+	lo := start.Add(-time.Second)
+	for lo.Before(end) {
+		hi := lo.Add(transitionStep)
+		if hi.After(end) {
+			hi = end
+		}
+
+		if !sameZone(lo, hi) {
+			tr := findTransition(lo, hi)
+			if !tr.At.Before(start) && tr.At.Before(end) {
+				result = append(result, tr)
+			}
+		}
+		lo = hi
+	}
+	return result
+}
+
+// findTransition finds the first second in (lo, hi] that is in a different zone from lo.
+func findTransition(lo, hi time.Time) Transition {
+	a, b := lo.Unix(), hi.Unix()
+	if hi.Nanosecond() > 0 {
+		b++
+	}
+	loc := lo.Location()
+	for b-a > 1 {
+		mid := a + (b-a)/2
+		if sameZone(lo, time.Unix(mid, 0).In(loc)) {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+
+	at := time.Unix(b, 0).In(loc)
+	oldName, oldOffset := lo.Zone()
+	newName, newOffset := at.Zone()
+	return Transition{
+		At:        at,
+		Date:      date.NewAt(at),
+		OldOffset: oldOffset,
+		NewOffset: newOffset,
+		OldName:   oldName,
+		NewName:   newName,
+	}
+}
+
+func sameZone(t1, t2 time.Time) bool {
+	n1, o1 := t1.Zone()
+	n2, o2 := t2.Zone()
+	return n1 == n2 && o1 == o2
+}
@@ -0,0 +1,57 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/simplylizz/date"
+)
+
+func TestTransitionsIn(t *testing.T) {
+	// in 2015, the UK clocks went forward on 29th March and back on 25th October
+	trs := TransitionsIn(NewYearOf(2015), london)
+	isEq(t, 0, len(trs), 2)
+
+	isEq(t, 0, trs[0].At.Equal(time.Date(2015, time.March, 29, 1, 0, 0, 0, time.UTC)), true, trs[0])
+	isEq(t, 0, trs[0].Date, d0329)
+	isEq(t, 0, trs[0].OldOffset, 0)
+	isEq(t, 0, trs[0].NewOffset, 3600)
+	isEq(t, 0, trs[0].OldName, "GMT")
+	isEq(t, 0, trs[0].NewName, "BST")
+	isEq(t, 0, trs[0].Change(), time.Hour)
+	isEq(t, 0, trs[0].String(), "2015-03-29T02:00:00+01:00 GMT→BST")
+	isEq(t, 0, d0329.LengthIn(london), 23*time.Hour)
+
+	isEq(t, 1, trs[1].At.Equal(time.Date(2015, time.October, 25, 1, 0, 0, 0, time.UTC)), true, trs[1])
+	isEq(t, 1, trs[1].Date, d1025)
+	isEq(t, 1, trs[1].Change(), -time.Hour)
+	isEq(t, 1, trs[1].NewName, "GMT")
+	isEq(t, 1, d1025.LengthIn(london), 25*time.Hour)
+
+	// the transition day alone, and the days either side of it
+	isEq(t, 2, len(TransitionsIn(OneDayRange(d0329), london)), 1)
+	isEq(t, 3, len(TransitionsIn(NewDateRange(d0320, d0329), london)), 0)
+	isEq(t, 4, len(TransitionsIn(NewDateRange(d0330, d0410), london)), 0)
+	isEq(t, 5, len(TransitionsIn(NewDateRange(d0330, d0329), london)), 1)
+
+	isEq(t, 6, len(TransitionsIn(NewYearOf(2015), time.UTC)), 0)
+	isEq(t, 7, len(TransitionsIn(EmptyRange(d0329), london)), 0)
+
+	// in 2018, the Brazilian clocks went forward at midnight on 4th November
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip(err)
+	}
+	d1104 := date.New(2018, time.November, 4)
+	trs = TransitionsIn(OneDayRange(d1104), saoPaulo)
+	isEq(t, 8, len(trs), 1)
+	isEq(t, 8, trs[0].At.Equal(time.Date(2018, time.November, 4, 3, 0, 0, 0, time.UTC)), true, trs[0])
+	isEq(t, 8, trs[0].Date, d1104)
+	isEq(t, 8, trs[0].Change(), time.Hour)
+	isEq(t, 9, len(TransitionsIn(OneDayRange(d1104.Add(-1)), saoPaulo)), 0)
+	isEq(t, 10, len(TransitionsIn(EmptyRange(d1104), saoPaulo)), 0)
+}
@@ -0,0 +1,88 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"time"
+
+	"github.com/simplylizz/date"
+)
+
+// WorkingTime measures how much open time lies between two instants, which can be in
+// either order. This is useful for service level agreements that only count business
+// hours. The result is the total duration along with the time spans that contribute to it,
+// clipped to the two instants and ordered by time.
+//
+// The durations are elapsed times, so a span that includes a daylight-saving change is
+// correspondingly shorter or longer than its wall-clock times suggest.
+func (oh *OpeningHours) WorkingTime(from, to time.Time) (time.Duration, []TimeSpan) {
+	if to.Before(from) {
+		from, to = to, from
+	}
+
+	first := date.NewAt(from.In(oh.loc)).Add(-1)
+	last := date.NewAt(to.In(oh.loc)).Add(1)
+
+	var total time.Duration
+	var result []TimeSpan
+	for _, ts := range oh.spans(first, last) {
+		s, e := ts.Start(), ts.End()
+		if s.Before(from) {
+			s = from
+		}
+		if e.After(to) {
+			e = to
+		}
+		if s.Before(e) {
+			total += e.Sub(s)
+			result = append(result, NewTimeSpan(s, e))
+		}
+	}
+	return total, result
+}
+
+// AddWorkingTime finds the instant at which an amount of open time after t has elapsed,
+// e.g. for "respond within 8 business hours". If the schedule is closed at t, the time
+// starts counting when it next opens. If the amount runs out exactly at a closing time,
+// the result is that closing time. If d is zero or negative, the result is t.
+//
+// The result is false if the schedule closes permanently before the amount has elapsed.
+func (oh *OpeningHours) AddWorkingTime(t time.Time, d time.Duration) (time.Time, bool) {
+	if d <= 0 {
+		return t, true
+	}
+
+	_, horizon := oh.horizon(t)
+
+	// spans are grouped by the date they start, so a span that wraps past midnight at the
+	// end of one week can overlap the first span of the next; the cursor ensures that no
+	// time is counted twice
+	cursor := t
+	for from := date.NewAt(t.In(oh.loc)).Add(-1); ; from = from.Add(7) {
+		found := false
+		for _, ts := range oh.spans(from, from.Add(7)) {
+			s, e := ts.Start(), ts.End()
+			if s.Before(cursor) {
+				s = cursor
+			}
+			if !s.Before(e) {
+				continue
+			}
+			found = true
+
+			available := e.Sub(s)
+			if d <= available {
+				return s.Add(d), true
+			}
+			d -= available
+			cursor = e
+		}
+
+		// beyond the horizon, each week is the same as the last
+		if !found && !from.Before(horizon) {
+			return time.Time{}, false
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2015 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkingTime(t *testing.T) {
+	oh := MustParseOpeningHours("Mo-Fr 09:00-17:00; PH off", london)
+	oh.AddPublicHolidays(d0403) // Good Friday
+
+	cases := []struct {
+		from, to time.Time
+		want     time.Duration
+		spans    int
+	}{
+		{lt(27, 10, 0), lt(27, 12, 30), 150 * time.Minute, 1},
+		{lt(27, 12, 30), lt(27, 10, 0), 150 * time.Minute, 1},
+		{lt(27, 7, 0), lt(27, 20, 0), 8 * time.Hour, 1},
+		{lt(27, 16, 0), lt(30, 10, 0), 2 * time.Hour, 2},
+		{lt(28, 9, 0), lt(29, 17, 0), 0, 0},
+		{lt(27, 9, 0), time.Date(2015, time.April, 7, 9, 0, 0, 0, london), 48 * time.Hour, 6},
+	}
+	for i, c := range cases {
+		d, spans := oh.WorkingTime(c.from, c.to)
+		isEq(t, i, d, c.want)
+		isEq(t, i, len(spans), c.spans)
+		var sum time.Duration
+		for _, ts := range spans {
+			sum += ts.Duration()
+		}
+		isEq(t, i, sum, c.want)
+	}
+}
+
+func TestWorkingTimeAcrossDST(t *testing.T) {
+	oh := MustParseOpeningHours("Sa,Su 00:00-24:00", london)
+
+	// the clocks go forward on Sunday 29th, which has only 23 hours
+	d, spans := oh.WorkingTime(lt(28, 12, 0), lt(30, 12, 0))
+	isEq(t, 0, d, 35*time.Hour)
+	isEq(t, 0, len(spans), 1)
+
+	end, ok := oh.AddWorkingTime(lt(28, 12, 0), 30*time.Hour)
+	isEq(t, 0, ok, true)
+	isEq(t, 0, end.Equal(lt(29, 19, 0)), true, end)
+}
+
+func TestAddWorkingTime(t *testing.T) {
+	oh := MustParseOpeningHours("Mo-Fr 09:00-17:00; PH off", london)
+	oh.AddPublicHolidays(d0403)
+
+	cases := []struct {
+		from time.Time
+		d    time.Duration
+		want time.Time
+	}{
+		{lt(27, 10, 0), 0, lt(27, 10, 0)},
+		{lt(27, 10, 0), 2 * time.Hour, lt(27, 12, 0)},
+		{lt(27, 10, 0), 7 * time.Hour, lt(27, 17, 0)},
+		{lt(27, 10, 0), 8 * time.Hour, lt(30, 10, 0)},
+		{lt(27, 18, 0), time.Hour, lt(30, 10, 0)},
+		{lt(28, 12, 0), 8 * time.Hour, lt(30, 17, 0)},
+		{time.Date(2015, time.April, 2, 16, 0, 0, 0, london), 2 * time.Hour, time.Date(2015, time.April, 6, 10, 0, 0, 0, london)},
+		{lt(27, 9, 0), 80 * time.Hour, time.Date(2015, time.April, 10, 17, 0, 0, 0, london)},
+	}
+	for i, c := range cases {
+		got, ok := oh.AddWorkingTime(c.from, c.d)
+		isEq(t, i, ok, true)
+		isEq(t, i, got.Equal(c.want), true, got, c.want)
+	}
+
+	never := MustParseOpeningHours("off; 2015 Apr 01 09:00-10:00", london)
+	_, ok := never.AddWorkingTime(lt(27, 12, 0), 2*time.Hour)
+	isEq(t, 0, ok, false)
+	got, ok := never.AddWorkingTime(lt(27, 12, 0), 30*time.Minute)
+	isEq(t, 0, ok, true)
+	isEq(t, 0, got.Equal(time.Date(2015, time.April, 1, 9, 30, 0, 0, london)), true, got)
+}
+
+func TestAddWorkingTimeRoundTripsAcrossWeeks(t *testing.T) {
+	// the wrapping range overlaps the early range on the next day, including across the
+	// boundary between the weeks in which AddWorkingTime gathers the spans
+	schedules := []*OpeningHours{
+		MustParseOpeningHours("Mo-Su 20:00-02:00,01:00-03:00", london),
+		MustParseOpeningHours("Mo-Fr 09:00-17:00; Su 22:00-26:00", london),
+		MustParseOpeningHours("24/7", london),
+	}
+	starts := []time.Time{lt(30, 12, 0), lt(29, 23, 30), lt(27, 1, 30)}
+
+	for i, oh := range schedules {
+		for _, from := range starts {
+			for h := 1; h <= 400; h += 3 {
+				d := time.Duration(h) * time.Hour
+				end, ok := oh.AddWorkingTime(from, d)
+				isEq(t, i, ok, true, oh, from, d)
+				got, _ := oh.WorkingTime(from, end)
+				isEq(t, i, got, d, oh, from, end)
+			}
+		}
+	}
+}